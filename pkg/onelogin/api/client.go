@@ -2,14 +2,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
-	"os"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
@@ -19,11 +18,23 @@ import (
 )
 
 // Client represents the API client.
+// A Client is safe for concurrent use by multiple goroutines once configured; its exported
+// fields must not be modified while requests are in flight.
 type Client struct {
-	HttpClient HTTPClient                    // HTTPClient interface for making HTTP requests
-	Auth       *authentication.Authenticator // Authenticator for managing authentication
-	OLdomain   string                        // OneLogin domain
+	HttpClient HTTPClient                 // HTTPClient interface for making HTTP requests
+	Auth       authentication.TokenSource // Source of access tokens, usually an *authentication.Authenticator
+	OLdomain   string                     // OneLogin domain
+	Subdomain  string                     // Tenant subdomain reported in telemetry; derived from OLdomain when empty
 	Timeout    time.Duration
+	Retry      *RetryPolicy // Retry policy for transient failures; nil disables retries
+	Throttle   bool         // Block before sending when the known rate limit budget is exhausted
+	Logger     Logger       // Logger for request diagnostics; discarded when nil
+	LogBodies  bool         // Log redacted request and response bodies at debug level
+	Middleware []Middleware // Middlewares wrapping every API call, outermost first; see Use
+	Tracer     Tracer       // Tracer starting a span per API call; no tracing when nil
+	Metrics    Metrics      // Metrics recording counters and durations of API calls; none when nil
+
+	limiter rateLimiter
 }
 
 // HTTPClient is an interface that defines the Do method for making HTTP requests.
@@ -31,45 +42,82 @@ type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
-// Authenticator is an interface that defines the GetToken method for retrieving authentication tokens.
-type Authenticator interface {
-	GetToken() (string, error)
-	NewAuthenticator() *authentication.Authenticator
+// NewClient creates a new instance of the API client configured from the ONELOGIN_* environment variables.
+func NewClient() (*Client, error) {
+	return NewClientWithConfig(ConfigFromEnv())
 }
 
-// NewClient creates a new instance of the API client.
-func NewClient() (*Client, error) {
-	subdomain := os.Getenv("ONELOGIN_SUBDOMAIN")
-	old := fmt.Sprintf("https://%s.onelogin.com", subdomain)
-	authenticator := authentication.NewAuthenticator(subdomain)
-	timeoutStr := os.Getenv("ONELOGIN_TIMEOUT")
-	timeout, err := strconv.Atoi(timeoutStr)
-	if err != nil || timeout <= 0 {
-		timeout = 10
+// NewClientWithConfig creates a new instance of the API client from an explicit configuration
+// and generates its first access token.
+func NewClientWithConfig(cfg Config) (*Client, error) {
+	if cfg.err != nil {
+		return nil, cfg.err
 	}
-	timeoutDuration := time.Second * time.Duration(timeout)
-
-	err = authenticator.GenerateToken()
+	old, err := cfg.ResolveBaseURL()
 	if err != nil {
 		return nil, err
 	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: timeout,
+		}
+	}
+
+	var auth authentication.TokenSource = cfg.TokenSource
+	if auth == nil {
+		auth = authentication.NewAuthenticatorWithConfig(authentication.Config{
+			Subdomain:    cfg.Subdomain,
+			BaseURL:      old,
+			TokenURL:     cfg.TokenURL,
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			HTTPClient:   httpClient,
+			RefreshSkew:  cfg.RefreshSkew,
+		})
+	}
+	if _, err := auth.Token(); err != nil {
+		return nil, err
+	}
+	retry := cfg.Retry
+	if retry == nil {
+		retry = DefaultRetryPolicy()
+	}
 	return &Client{
-		HttpClient: &http.Client{
-			Timeout: timeoutDuration,
-		},
-		Auth:     authenticator,
-		OLdomain: old,
+		HttpClient: httpClient,
+		Auth:       auth,
+		OLdomain:   old,
+		Timeout:    timeout,
+		Retry:      retry,
+		Throttle:   cfg.Throttle,
+		Logger:     cfg.Logger,
+		LogBodies:  cfg.LogBodies,
+		Middleware: cfg.Middleware,
+		Subdomain:  cfg.Subdomain,
+		Tracer:     cfg.Tracer,
+		Metrics:    cfg.Metrics,
 	}, nil
 }
 
-// newRequest creates a new HTTP request with the specified method, path, query parameters, and request body.
-func (c *Client) newRequest(method string, path *string, queryParams mod.Queryable, body io.Reader) (*http.Request, error) {
+// logger returns the logger used for request diagnostics.
+func (c *Client) logger() Logger {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+	return c.Logger
+}
+
+// newRequest creates a new HTTP request bound to ctx with the specified method, path, query parameters, and request body.
+func (c *Client) newRequest(ctx context.Context, method string, path *string, queryParams mod.Queryable, body io.Reader) (*http.Request, error) {
 
 	p, err := utl.AddQueryToPath(*path, queryParams)
 	if err != nil {
 		return nil, err
 	}
-	log.Println("Path:", p)
 	// Parse the OneLogin domain and path
 	u, err := url.Parse(c.OLdomain + p)
 	if err != nil {
@@ -77,22 +125,20 @@ func (c *Client) newRequest(method string, path *string, queryParams mod.Queryab
 	}
 
 	// Create a new HTTP request
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get authentication token
-	log.Println("Getting authentication token...")
-	tk, err := c.Auth.GetToken()
+	tk, err := authentication.TokenFromSource(ctx, c.Auth)
 	if err != nil {
-		log.Println("Error getting authentication token:", err)
-		return nil, olerror.NewAuthenticationError("Access Token Retrieval Error")
+		c.logger().Error("access token retrieval failed", "method", method, "path", u.Path, "error", err)
+		return nil, olerror.WrapAuthenticationError("Access Token Retrieval Error", err)
 	}
-	log.Println("Authentication token retrieved successfully.")
 
 	// Set request headers
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tk))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tk.AccessToken))
 	req.Header.Set("Content-Type", "application/json")
 
 	return req, nil
@@ -100,7 +146,12 @@ func (c *Client) newRequest(method string, path *string, queryParams mod.Queryab
 
 // Get sends a GET request to the specified path with the given query parameters.
 func (c *Client) Get(path *string, queryParams mod.Queryable) (*http.Response, error) {
-	req, err := c.newRequest(http.MethodGet, path, queryParams, http.NoBody)
+	return c.GetWithContext(context.Background(), path, queryParams)
+}
+
+// GetWithContext sends a GET request bound to ctx to the specified path with the given query parameters.
+func (c *Client) GetWithContext(ctx context.Context, path *string, queryParams mod.Queryable) (*http.Response, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, path, queryParams, http.NoBody)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +161,12 @@ func (c *Client) Get(path *string, queryParams mod.Queryable) (*http.Response, e
 
 // Delete sends a DELETE request to the specified path with the given query parameters.
 func (c *Client) Delete(path *string) (*http.Response, error) {
-	req, err := c.newRequest(http.MethodDelete, path, nil, http.NoBody)
+	return c.DeleteWithContext(context.Background(), path)
+}
+
+// DeleteWithContext sends a DELETE request bound to ctx to the specified path.
+func (c *Client) DeleteWithContext(ctx context.Context, path *string) (*http.Response, error) {
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil, http.NoBody)
 	if err != nil {
 		return nil, err
 	}
@@ -120,12 +176,17 @@ func (c *Client) Delete(path *string) (*http.Response, error) {
 
 // Delete sends a DELETE request to the specified path with the given query parameters and request body.
 func (c *Client) DeleteWithBody(path *string, body interface{}) (*http.Response, error) {
+	return c.DeleteWithBodyWithContext(context.Background(), path, body)
+}
+
+// DeleteWithBodyWithContext sends a DELETE request bound to ctx to the specified path with the given request body.
+func (c *Client) DeleteWithBodyWithContext(ctx context.Context, path *string, body interface{}) (*http.Response, error) {
 	// Convert request body to JSON
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
-	req, err := c.newRequest(http.MethodDelete, path, nil, bytes.NewReader(jsonBody))
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil, bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -135,13 +196,18 @@ func (c *Client) DeleteWithBody(path *string, body interface{}) (*http.Response,
 
 // Post sends a POST request to the specified path with the given query parameters and request body.
 func (c *Client) Post(path *string, body interface{}) (*http.Response, error) {
+	return c.PostWithContext(context.Background(), path, body)
+}
+
+// PostWithContext sends a POST request bound to ctx to the specified path with the given request body.
+func (c *Client) PostWithContext(ctx context.Context, path *string, body interface{}) (*http.Response, error) {
 	// Convert request body to JSON
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := c.newRequest(http.MethodPost, path, nil, bytes.NewReader(jsonBody))
+	req, err := c.newRequest(ctx, http.MethodPost, path, nil, bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -151,13 +217,18 @@ func (c *Client) Post(path *string, body interface{}) (*http.Response, error) {
 
 // Put sends a PUT request to the specified path with the given query parameters and request body.
 func (c *Client) Put(path *string, body interface{}) (*http.Response, error) {
+	return c.PutWithContext(context.Background(), path, body)
+}
+
+// PutWithContext sends a PUT request bound to ctx to the specified path with the given request body.
+func (c *Client) PutWithContext(ctx context.Context, path *string, body interface{}) (*http.Response, error) {
 	// Convert request body to JSON
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := c.newRequest(http.MethodPut, path, nil, bytes.NewReader(jsonBody))
+	req, err := c.newRequest(ctx, http.MethodPut, path, nil, bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, err
 	}
@@ -165,27 +236,139 @@ func (c *Client) Put(path *string, body interface{}) (*http.Response, error) {
 	return c.sendRequest(req)
 }
 
-// sendRequest sends the specified HTTP request and returns the HTTP response.
+// sendRequest sends the specified HTTP request through the client's middleware chain and returns the HTTP response.
+// A 401 response triggers a single token refresh when the token source supports renewal, and transient failures are retried according to c.Retry.
+// Every response updates the tracked rate limit budget, which throttles outgoing requests when c.Throttle is set.
+// The request body is rewound before every new attempt.
 func (c *Client) sendRequest(req *http.Request) (*http.Response, error) {
-	resp, err := c.HttpClient.Do(req)
-	if err != nil {
-		return nil, err
+	return c.chain()(req)
+}
+
+// retryLayer retries transport errors and retryable statuses according to c.Retry. POST and PATCH
+// requests are only retried after a 429 with Retry-After, unless c.Retry.RetryNonIdempotent is set.
+func (c *Client) retryLayer(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		for attempt := 0; ; attempt++ {
+			resp, err := next(req)
+			var delay time.Duration
+			if err != nil {
+				if !isRetryableError(err) || !c.Retry.retryableMethod(req.Method) || !c.Retry.canRetry(attempt) {
+					c.logger().Error("request failed", "method", req.Method, "path", req.URL.Path, "error", err)
+					return nil, err
+				}
+				delay = c.Retry.backoff(attempt)
+				countRetry(req.Context())
+				c.logger().Warn("retrying request", "method", req.Method, "path", req.URL.Path, "attempt", attempt+1, "delay", delay, "error", err)
+			} else {
+				if !c.Retry.retryableResponse(req.Method, resp) || !c.Retry.canRetry(attempt) {
+					return resp, nil
+				}
+				delay = c.Retry.delay(attempt, resp)
+				countRetry(req.Context())
+				c.logger().Warn("retrying request", "method", req.Method, "path", req.URL.Path, "attempt", attempt+1, "delay", delay, "status", resp.StatusCode)
+				drainBody(resp)
+			}
+			if err := sleepContext(req.Context(), delay); err != nil {
+				return nil, err
+			}
+		}
 	}
+}
 
-	// Check for API errors
-	if resp.StatusCode == http.StatusUnauthorized {
-		// Regenerate the token and reattempt the request
-		err := c.Auth.GenerateToken()
+// renewLayer renews the access token once and resends the request when it is rejected with a 401.
+func (c *Client) renewLayer(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		resp, err := next(req)
+		renewer, canRenew := c.Auth.(authentication.Renewer)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized || !canRenew {
+			return resp, err
+		}
+		drainBody(resp)
+
+		// Regenerate the token unless another request already did, and reattempt the request
+		c.logger().Info("access token rejected, renewing", "method", req.Method, "path", req.URL.Path)
+		stale := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		tk, err := renewer.RenewToken(req.Context(), stale)
 		if err != nil {
-			return nil, olerror.NewAuthenticationError("Failed to refresh access token")
+			return nil, olerror.WrapAuthenticationError("Failed to refresh access token", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tk))
+		return next(req)
+	}
+}
+
+// logLayer logs every attempt and its response at debug level.
+func (c *Client) logLayer(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		c.logRequest(req)
+		start := time.Now()
+		resp, err := next(req)
+		if err == nil {
+			c.logResponse(req, resp, time.Since(start))
 		}
+		return resp, err
+	}
+}
 
-		// Retry the request
-		resp, err = c.HttpClient.Do(req)
+// rateLimitLayer records the rate limit budget of every response and, when c.Throttle is set,
+// blocks before sending while the budget is exhausted.
+func (c *Client) rateLimitLayer(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if c.Throttle {
+			if err := c.limiter.acquire(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := next(req)
+		if err == nil {
+			c.limiter.update(resp.Header)
+		}
+		return resp, err
+	}
+}
+
+// logRequest logs an outgoing request at debug level with its credentials redacted.
+func (c *Client) logRequest(req *http.Request) {
+	args := []any{"method", req.Method, "path", req.URL.Path, "query", req.URL.RawQuery, "header", RedactHeader(req.Header)}
+	if c.LogBodies {
+		if b, err := ReadRequestBody(req); err == nil {
+			args = append(args, "body", RedactBody(req.URL.Path, b))
+		}
+	}
+	c.logger().Debug("request", args...)
+}
+
+// logResponse logs a response at debug level with its secrets redacted. When bodies are logged,
+// the response body is buffered and replaced so the caller can still read it.
+func (c *Client) logResponse(req *http.Request, resp *http.Response, elapsed time.Duration) {
+	args := []any{"method", req.Method, "path", req.URL.Path, "status", resp.StatusCode, "duration", elapsed, "request_id", resp.Header.Get(RequestIDHeader), "header", RedactHeader(resp.Header)}
+	if c.LogBodies {
+		if b, err := ReadResponseBody(resp); err == nil {
+			args = append(args, "body", RedactBody(req.URL.Path, b))
+		}
+	}
+	c.logger().Debug("response", args...)
+}
+
+// rewindRequest returns a copy of req with a fresh body so it can be sent again.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
 		if err != nil {
 			return nil, err
 		}
+		r.Body = body
 	}
+	return r, nil
+}
 
-	return resp, nil
+// Close revokes the current access token when the token source supports it and clears the
+// cached token and rate limit state. The client generates a new token if it is used again.
+func (c *Client) Close(ctx context.Context) error {
+	c.limiter.reset()
+	if r, ok := c.Auth.(authentication.Revoker); ok {
+		return r.RevokeCurrentToken(ctx)
+	}
+	return nil
 }
@@ -0,0 +1,270 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+)
+
+// Environment variables read by ConfigFromEnv and FromEnv.
+const (
+	EnvSubdomain    string = "ONELOGIN_SUBDOMAIN"
+	EnvClientID     string = "ONELOGIN_CLIENT_ID"
+	EnvClientSecret string = "ONELOGIN_CLIENT_SECRET"
+	EnvTimeout      string = "ONELOGIN_TIMEOUT"
+	EnvRegion       string = "ONELOGIN_REGION"
+	EnvBaseURL      string = "ONELOGIN_BASE_URL"
+)
+
+// DefaultTimeout is the HTTP timeout used when none is configured.
+const DefaultTimeout = 10 * time.Second
+
+// Config holds everything needed to construct a Client for a single OneLogin tenant.
+type Config struct {
+	Subdomain    string                     // OneLogin subdomain, e.g. "acme" for acme.onelogin.com
+	Region       string                     // Shard region such as "us" or "eu"; selects https://api.<region>.onelogin.com
+	BaseURL      string                     // API base URL; overrides Subdomain and Region when set
+	TokenURL     string                     // Token endpoint URL; BaseURL + authentication.TkPath when empty
+	ClientID     string                     // API credential client ID
+	ClientSecret string                     // API credential client secret
+	TokenSource  authentication.TokenSource // Source of access tokens; replaces ClientID and ClientSecret when set
+	Timeout      time.Duration              // HTTP timeout; DefaultTimeout when zero
+	RefreshSkew  time.Duration              // Refresh access tokens this long before expiry; one minute when zero
+	HTTPClient   HTTPClient                 // HTTP client used for API and token requests; built from Timeout when nil
+	Retry        *RetryPolicy               // Retry policy for transient failures; DefaultRetryPolicy when nil
+	Throttle     bool                       // Block before sending when the known rate limit budget is exhausted
+	Logger       Logger                     // Logger for request diagnostics; discarded when nil
+	LogBodies    bool                       // Log redacted request and response bodies at debug level
+	Middleware   []Middleware               // Middlewares wrapping every API call, outermost first
+	Tracer       Tracer                     // Tracer starting a span per API call; no tracing when nil
+	Metrics      Metrics                    // Metrics recording counters and durations of API calls; none when nil
+
+	err error // Deferred error from an option, reported by NewClientWithConfig
+}
+
+// Option configures a Config.
+type Option func(*Config)
+
+// NewConfig builds a Config by applying opts in order to an empty Config.
+func NewConfig(opts ...Option) Config {
+	var cfg Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// ConfigFromEnv builds a Config from the ONELOGIN_* environment variables.
+// When ONELOGIN_PROFILE is set, that profile is loaded from the credentials file first
+// and the other variables override its values.
+func ConfigFromEnv() Config {
+	if os.Getenv(authentication.EnvProfile) != "" {
+		return NewConfig(WithProfile(""), FromEnv())
+	}
+	return NewConfig(FromEnv())
+}
+
+// ConfigFromProfile builds a Config from a named profile of the default credentials file.
+// An empty name selects $ONELOGIN_PROFILE, or "default" when it is unset.
+func ConfigFromProfile(name string) (Config, error) {
+	cfg := NewConfig(WithProfile(name))
+	return cfg, cfg.err
+}
+
+// FromEnv sets every field for which an ONELOGIN_* environment variable is present.
+// Place it before other options to use the environment as a fallback only.
+func FromEnv() Option {
+	return func(cfg *Config) {
+		if v := os.Getenv(EnvSubdomain); v != "" {
+			cfg.Subdomain = v
+		}
+		if v := os.Getenv(EnvRegion); v != "" {
+			cfg.Region = v
+		}
+		if v := os.Getenv(EnvBaseURL); v != "" {
+			cfg.BaseURL = v
+		}
+		if v := os.Getenv(EnvClientID); v != "" {
+			cfg.ClientID = v
+		}
+		if v := os.Getenv(EnvClientSecret); v != "" {
+			cfg.ClientSecret = v
+		}
+		if timeout, err := strconv.Atoi(os.Getenv(EnvTimeout)); err == nil && timeout > 0 {
+			cfg.Timeout = time.Second * time.Duration(timeout)
+		}
+	}
+}
+
+// WithProfile applies a named profile from the default credentials file (~/.onelogin/credentials
+// or $ONELOGIN_CREDENTIALS_FILE). An empty name selects $ONELOGIN_PROFILE, or "default" when it
+// is unset. Load errors are reported when the client is created.
+func WithProfile(name string) Option {
+	return WithProfileFile("", name)
+}
+
+// WithProfileFile is like WithProfile for a credentials file at an explicit path.
+func WithProfileFile(path, name string) Option {
+	return func(cfg *Config) {
+		profile, err := authentication.LoadProfile(path, name)
+		if err != nil {
+			cfg.err = err
+			return
+		}
+		if profile.Subdomain != "" {
+			cfg.Subdomain = profile.Subdomain
+		}
+		if profile.Region != "" {
+			cfg.Region = profile.Region
+		}
+		if profile.BaseURL != "" {
+			cfg.BaseURL = profile.BaseURL
+		}
+		if profile.ClientID != "" {
+			cfg.ClientID = profile.ClientID
+		}
+		if profile.ClientSecret != "" {
+			cfg.ClientSecret = profile.ClientSecret
+		}
+		if profile.Timeout > 0 {
+			cfg.Timeout = profile.Timeout
+		}
+	}
+}
+
+// WithSubdomain sets the OneLogin subdomain.
+func WithSubdomain(subdomain string) Option {
+	return func(cfg *Config) {
+		cfg.Subdomain = subdomain
+	}
+}
+
+// WithRegion selects the regional API host, e.g. "eu" for https://api.eu.onelogin.com.
+func WithRegion(region string) Option {
+	return func(cfg *Config) {
+		cfg.Region = region
+	}
+}
+
+// WithBaseURL sets the API base URL, e.g. a vanity domain or an httptest server URL.
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *Config) {
+		cfg.BaseURL = baseURL
+	}
+}
+
+// WithTokenURL sets the token endpoint URL when it is not hosted under the API base URL.
+func WithTokenURL(tokenURL string) Option {
+	return func(cfg *Config) {
+		cfg.TokenURL = tokenURL
+	}
+}
+
+// WithCredentials sets the API credential client ID and secret.
+func WithCredentials(clientID, clientSecret string) Option {
+	return func(cfg *Config) {
+		cfg.ClientID = clientID
+		cfg.ClientSecret = clientSecret
+	}
+}
+
+// WithTokenSource sets a custom source of access tokens, such as authentication.StaticTokenSource
+// or authentication.DefaultTokenSource.
+func WithTokenSource(src authentication.TokenSource) Option {
+	return func(cfg *Config) {
+		cfg.TokenSource = src
+	}
+}
+
+// WithTimeout sets the HTTP timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.Timeout = timeout
+	}
+}
+
+// WithRefreshSkew sets how long before expiry access tokens are proactively refreshed.
+func WithRefreshSkew(skew time.Duration) Option {
+	return func(cfg *Config) {
+		cfg.RefreshSkew = skew
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(cfg *Config) {
+		cfg.HTTPClient = client
+	}
+}
+
+// WithLogger sets the logger used for request diagnostics, e.g. a *slog.Logger or NewStdLogger(log.Default(), LevelInfo).
+func WithLogger(logger Logger) Option {
+	return func(cfg *Config) {
+		cfg.Logger = logger
+	}
+}
+
+// WithRetryPolicy sets the retry policy for transient failures; nil disables retries.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(cfg *Config) {
+		if policy == nil {
+			policy = &RetryPolicy{MaxAttempts: 1}
+		}
+		cfg.Retry = policy
+	}
+}
+
+// WithThrottle makes the client block before sending a request when the rate limit budget
+// reported by previous responses is exhausted, until the window resets.
+func WithThrottle(throttle bool) Option {
+	return func(cfg *Config) {
+		cfg.Throttle = throttle
+	}
+}
+
+// WithLogBodies logs redacted request and response bodies at debug level.
+func WithLogBodies(logBodies bool) Option {
+	return func(cfg *Config) {
+		cfg.LogBodies = logBodies
+	}
+}
+
+// WithMiddleware appends middlewares wrapping every API call, e.g. BeforeRequest hooks adding headers.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(cfg *Config) {
+		cfg.Middleware = append(cfg.Middleware, mw...)
+	}
+}
+
+// WithTracer sets the tracer starting a span for every API call.
+func WithTracer(tracer Tracer) Option {
+	return func(cfg *Config) {
+		cfg.Tracer = tracer
+	}
+}
+
+// WithMetrics sets the recorder of API call counters and durations.
+func WithMetrics(metrics Metrics) Option {
+	return func(cfg *Config) {
+		cfg.Metrics = metrics
+	}
+}
+
+// ResolveBaseURL returns the API base URL, derived from BaseURL, Region or Subdomain in that order.
+func (cfg Config) ResolveBaseURL() (string, error) {
+	switch {
+	case cfg.BaseURL != "":
+		return strings.TrimRight(cfg.BaseURL, "/"), nil
+	case cfg.Region != "":
+		return fmt.Sprintf("https://api.%s.onelogin.com", strings.ToLower(cfg.Region)), nil
+	case cfg.Subdomain != "":
+		return fmt.Sprintf("https://%s.onelogin.com", cfg.Subdomain), nil
+	default:
+		return "", olerror.NewSDKError("Missing subdomain (ONELOGIN_SUBDOMAIN), region or base URL")
+	}
+}
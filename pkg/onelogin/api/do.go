@@ -0,0 +1,81 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
+
+// RequestIDHeader is the response header carrying the identifier OneLogin assigns to each request.
+const RequestIDHeader string = "X-Request-Id"
+
+// Response describes the HTTP response behind a decoded API result.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	RequestID  string // Value of the X-Request-Id header, useful when contacting OneLogin support
+
+	// Pagination of list endpoints, from the response headers or the API v1 body envelope
+	AfterCursor  string
+	BeforeCursor string
+	CurrentPage  int
+	TotalPages   int
+	TotalCount   int
+	NextLink     string // URL of the next page from the Link header
+}
+
+func newResponse(resp *http.Response) *Response {
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RequestID:  resp.Header.Get(RequestIDHeader),
+	}
+}
+
+// Do sends a request bound to ctx and decodes a successful JSON response into T.
+// The body is encoded as JSON unless it is nil, and query is appended to path.
+// Response bodies wrapped in the API v1 envelope are unwrapped; with T = interface{} the body is
+// returned as the generic maps and slices of utilities.CheckHTTPResponse instead.
+// The returned Response is set whenever the API answered, including on a failure status, which is
+// reported as an *olerror.APIError.
+func Do[T any](ctx context.Context, c *Client, method, path string, query mod.Queryable, body interface{}) (T, *Response, error) {
+	var out T
+
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return out, nil, err
+		}
+		reader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := c.newRequest(ctx, method, &path, query, reader)
+	if err != nil {
+		return out, nil, err
+	}
+	resp, err := c.sendRequest(req)
+	if err != nil {
+		return out, nil, err
+	}
+
+	meta := newResponse(resp)
+	envelope, err := utl.DecodePaginatedHTTPResponse(resp, &out)
+	if err != nil {
+		var apiErr *olerror.APIError
+		if errors.As(err, &apiErr) && apiErr.Method == "" {
+			apiErr.Method, apiErr.Path = req.Method, req.URL.Path
+		}
+		var zero T
+		return zero, meta, err
+	}
+	meta.setPagination(resp.Header, envelope)
+	return out, meta, nil
+}
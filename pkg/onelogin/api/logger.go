@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Logger receives the client's request diagnostics. Each method takes a message followed by
+// alternating key/value pairs, so a *slog.Logger from log/slog can be used directly.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// Level is the severity of a log message.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// nopLogger discards every message. It is used when no logger is configured.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// stdLogger writes messages at or above a minimum level to a *log.Logger as "LEVEL msg key=value ...".
+type stdLogger struct {
+	logger *log.Logger
+	min    Level
+}
+
+// NewStdLogger adapts a *log.Logger to the Logger interface, dropping messages below min.
+// A nil logger writes to the standard logger.
+func NewStdLogger(logger *log.Logger, min Level) Logger {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &stdLogger{logger: logger, min: min}
+}
+
+func (l *stdLogger) Debug(msg string, args ...any) { l.log(LevelDebug, msg, args) }
+func (l *stdLogger) Info(msg string, args ...any)  { l.log(LevelInfo, msg, args) }
+func (l *stdLogger) Warn(msg string, args ...any)  { l.log(LevelWarn, msg, args) }
+func (l *stdLogger) Error(msg string, args ...any) { l.log(LevelError, msg, args) }
+
+func (l *stdLogger) log(level Level, msg string, args []any) {
+	if level < l.min {
+		return
+	}
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			fmt.Fprintf(&b, " !BADKEY=%v", args[i])
+			break
+		}
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	l.logger.Print(b.String())
+}
+
+// Redacted replaces secret values in log output.
+const Redacted string = "[REDACTED]"
+
+// redactedHeaders are the request and response headers whose values are never logged.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields are the JSON keys whose values are never logged, at any depth: passwords of
+// models.User, client credentials and tokens.
+var redactedFields = map[string]bool{
+	"password":              true,
+	"password_confirmation": true,
+	"salt":                  true,
+	"client_secret":         true,
+	"access_token":          true,
+	"refresh_token":         true,
+}
+
+// RedactHeader returns a copy of header with credentials replaced by Redacted.
+func RedactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, Redacted)
+		}
+	}
+	return redacted
+}
+
+// RedactBody returns a JSON request or response body with passwords, client secrets, tokens
+// and smart hook environment variable values replaced by Redacted. Bodies that are not JSON
+// are returned as a placeholder giving their size.
+func RedactBody(path string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Sprintf("[%d bytes]", len(body))
+	}
+	// Environment variable values are secrets of the hooks that use them.
+	envVars := strings.Contains(path, "/hooks/envs")
+	redacted, err := json.Marshal(redactValue(data, envVars))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes]", len(body))
+	}
+	return string(redacted)
+}
+
+// redactValue replaces secret fields in decoded JSON. When envVars is set, the values of
+// environment variable objects are redacted as well.
+func redactValue(v interface{}, envVars bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			switch {
+			case redactedFields[strings.ToLower(key)]:
+				v[key] = Redacted
+			case key == "value" && envVars:
+				v[key] = Redacted
+			case key == "env_vars":
+				v[key] = redactValue(value, true)
+			default:
+				v[key] = redactValue(value, envVars)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value, envVars)
+		}
+	}
+	return v
+}
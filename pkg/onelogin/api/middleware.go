@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// RoundTripFunc sends a request and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the function sending a request, e.g. to add headers, inspect responses or
+// record audit events. A middleware calls next to pass the request on and may modify the request
+// before and the response after.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use appends middlewares to the client's chain. Middlewares run in the order they were added,
+// the first one being the outermost, and all of them wrap the built-in retry, token renewal,
+// logging and rate limit layers: they are called once per API call and see the final response.
+// Use must not be called while requests are in flight.
+func (c *Client) Use(mw ...Middleware) {
+	c.Middleware = append(c.Middleware, mw...)
+}
+
+// BeforeRequest returns a Middleware calling fn before every request is sent. The request carries
+// the method, the resolved URL and the headers; its body can be read with ReadRequestBody.
+// An error from fn aborts the request and is returned to the caller.
+func BeforeRequest(fn func(req *http.Request) error) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if err := fn(req); err != nil {
+				return nil, err
+			}
+			return next(req)
+		}
+	}
+}
+
+// AfterResponse returns a Middleware calling fn with every request and its response, or with the
+// error that prevented a response. The response body can be read with ReadResponseBody.
+func AfterResponse(fn func(req *http.Request, resp *http.Response, err error)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+			fn(req, resp, err)
+			return resp, err
+		}
+	}
+}
+
+// ReadRequestBody returns the body of a request without consuming it.
+func ReadRequestBody(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		return nil, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// ReadResponseBody returns the body of a response and replaces it with a copy, so it can still
+// be read by the caller.
+func ReadResponseBody(resp *http.Response) ([]byte, error) {
+	if resp.Body == nil {
+		return nil, nil
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	return b, err
+}
+
+// chain returns the function sending requests through the client's middlewares and the built-in
+// layers, from the outermost to the innermost: user middlewares, retries, token renewal,
+// logging, rate limiting and finally the HTTP client. Telemetry wraps the whole chain so that
+// middlewares see the span of the call in the request context.
+func (c *Client) chain() RoundTripFunc {
+	layers := make([]Middleware, 0, len(c.Middleware)+5)
+	layers = append(layers, c.telemetryLayer)
+	layers = append(layers, c.Middleware...)
+	layers = append(layers, c.retryLayer, c.renewLayer, c.logLayer, c.rateLimitLayer)
+
+	send := c.transport
+	for i := len(layers) - 1; i >= 0; i-- {
+		send = layers[i](send)
+	}
+	return send
+}
+
+// transport sends a copy of req with a fresh body through the HTTP client, so that every attempt
+// can read the body from the start.
+func (c *Client) transport(req *http.Request) (*http.Response, error) {
+	r, err := rewindRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.HttpClient.Do(r)
+}
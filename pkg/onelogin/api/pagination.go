@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
+
+const (
+	AfterCursorHeader  string = "After-Cursor"
+	BeforeCursorHeader string = "Before-Cursor"
+	CurrentPageHeader  string = "Current-Page"
+	TotalPagesHeader   string = "Total-Pages"
+	TotalCountHeader   string = "Total-Count"
+	LinkHeader         string = "Link"
+)
+
+// setPagination fills the pagination fields of r from the response headers, falling back to the
+// cursors of an API v1 body envelope.
+func (r *Response) setPagination(header http.Header, envelope *utl.Pagination) {
+	r.AfterCursor = header.Get(AfterCursorHeader)
+	r.BeforeCursor = header.Get(BeforeCursorHeader)
+	r.CurrentPage, _ = strconv.Atoi(header.Get(CurrentPageHeader))
+	r.TotalPages, _ = strconv.Atoi(header.Get(TotalPagesHeader))
+	r.TotalCount, _ = strconv.Atoi(header.Get(TotalCountHeader))
+	r.NextLink = parseNextLink(header.Get(LinkHeader))
+
+	if envelope != nil {
+		if r.AfterCursor == "" {
+			r.AfterCursor = envelope.AfterCursor
+		}
+		if r.BeforeCursor == "" {
+			r.BeforeCursor = envelope.BeforeCursor
+		}
+		if r.NextLink == "" {
+			r.NextLink = envelope.NextLink
+		}
+	}
+}
+
+// parseNextLink returns the URL of the rel="next" entry of an RFC 8288 Link header.
+func parseNextLink(link string) string {
+	for _, entry := range strings.Split(link, ",") {
+		parts := strings.Split(entry, ";")
+		target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+		for _, param := range parts[1:] {
+			if strings.ReplaceAll(strings.TrimSpace(param), `"`, "") == "rel=next" {
+				return target
+			}
+		}
+	}
+	return ""
+}
+
+// PageRequest selects the page a Pager fetches next.
+type PageRequest struct {
+	Cursor string // Cursor of the page to fetch; empty for the first page of a cursor-based endpoint
+	Page   int    // Number of the page to fetch; zero for the first page
+}
+
+// next returns the request for the page following the one described by r, and false on the last page.
+func (r *Response) next(current PageRequest) (PageRequest, bool) {
+	if r.AfterCursor != "" {
+		return PageRequest{Cursor: r.AfterCursor}, true
+	}
+	if r.NextLink != "" {
+		if u, err := url.Parse(r.NextLink); err == nil {
+			q := u.Query()
+			for _, key := range []string{"after_cursor", "cursor"} {
+				if cursor := q.Get(key); cursor != "" {
+					return PageRequest{Cursor: cursor}, true
+				}
+			}
+			if page, err := strconv.Atoi(q.Get("page")); err == nil && page > 0 {
+				return PageRequest{Page: page}, true
+			}
+		}
+	}
+	if r.TotalPages > 0 {
+		page := r.CurrentPage
+		if page == 0 {
+			page = current.Page
+			if page == 0 {
+				page = 1
+			}
+		}
+		if page < r.TotalPages {
+			return PageRequest{Page: page + 1}, true
+		}
+	}
+	return PageRequest{}, false
+}
+
+// PageFunc fetches one page of a list endpoint.
+type PageFunc[T any] func(ctx context.Context, page PageRequest) ([]T, *Response, error)
+
+// Pager iterates over a list endpoint, following the After-Cursor, Link and Total-Pages response
+// headers until the last page. Use NextPage and Page to walk page by page, Next and Item to walk
+// item by item, or All to collect every remaining item. A Pager is not safe for concurrent use.
+type Pager[T any] struct {
+	ctx     context.Context
+	fetch   PageFunc[T]
+	request PageRequest
+	done    bool
+
+	page []T
+	resp *Response
+	err  error
+
+	index int
+}
+
+// NewPager returns a Pager fetching pages with fetch, bound to ctx.
+func NewPager[T any](ctx context.Context, fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{ctx: ctx, fetch: fetch}
+}
+
+// NextPage fetches the next page and reports whether one was available.
+// It returns false after the last page or on error; check Err afterwards.
+func (p *Pager[T]) NextPage() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	page, resp, err := p.fetch(p.ctx, p.request)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	p.page, p.resp, p.index = page, resp, 0
+
+	next, ok := resp.next(p.request)
+	if !ok || len(page) == 0 || next == p.request {
+		p.done = true
+	}
+	p.request = next
+	return len(page) > 0 || !p.done
+}
+
+// Page returns the items of the current page.
+func (p *Pager[T]) Page() []T {
+	return p.page
+}
+
+// Response returns the response metadata of the current page.
+func (p *Pager[T]) Response() *Response {
+	return p.resp
+}
+
+// Next advances to the next item, fetching pages as needed, and reports whether one was available.
+func (p *Pager[T]) Next() bool {
+	for p.index >= len(p.page) {
+		if !p.NextPage() {
+			return false
+		}
+	}
+	p.index++
+	return true
+}
+
+// Item returns the current item after a call to Next.
+func (p *Pager[T]) Item() T {
+	return p.page[p.index-1]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All collects the items of every remaining page.
+func (p *Pager[T]) All() ([]T, error) {
+	var items []T
+	if p.index > 0 {
+		items = append(items, p.page[p.index:]...)
+		p.index = len(p.page)
+	}
+	for p.NextPage() {
+		items = append(items, p.page...)
+		p.index = len(p.page)
+	}
+	return items, p.err
+}
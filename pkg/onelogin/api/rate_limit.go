@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	RateLimitLimitHeader     string = "X-RateLimit-Limit"
+	RateLimitRemainingHeader string = "X-RateLimit-Remaining"
+	RateLimitResetHeader     string = "X-RateLimit-Reset"
+)
+
+// RateLimit describes the request budget reported by OneLogin in the X-RateLimit-* response headers.
+type RateLimit struct {
+	Limit     int       // Number of requests allowed in the current window
+	Remaining int       // Number of requests left in the current window
+	Reset     time.Time // Time at which the window resets
+}
+
+// ParseRateLimit extracts the rate limit budget from response headers.
+// It reports false when the headers are missing or malformed.
+func ParseRateLimit(header http.Header, now time.Time) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get(RateLimitLimitHeader))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get(RateLimitRemainingHeader))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	rl := RateLimit{Limit: limit, Remaining: remaining}
+
+	// OneLogin reports the number of seconds until the window resets; tolerate epoch timestamps as well.
+	if reset, err := strconv.ParseInt(header.Get(RateLimitResetHeader), 10, 64); err == nil {
+		if reset > 1000000000 {
+			rl.Reset = time.Unix(reset, 0)
+		} else {
+			rl.Reset = now.Add(time.Duration(reset) * time.Second)
+		}
+	}
+	return rl, true
+}
+
+// rateLimiter is a client-side token bucket seeded from the rate limit headers of previous responses.
+type rateLimiter struct {
+	mu    sync.Mutex
+	state RateLimit
+	known bool
+}
+
+// update records the budget reported by a response.
+func (l *rateLimiter) update(header http.Header) {
+	rl, ok := ParseRateLimit(header, time.Now())
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	l.state = rl
+	l.known = true
+	l.mu.Unlock()
+}
+
+// reset forgets the tracked budget.
+func (l *rateLimiter) reset() {
+	l.mu.Lock()
+	l.state = RateLimit{}
+	l.known = false
+	l.mu.Unlock()
+}
+
+// current returns the last known budget.
+func (l *rateLimiter) current() (RateLimit, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state, l.known
+}
+
+// acquire takes a token from the bucket, blocking until the window resets when the budget is exhausted.
+func (l *rateLimiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		if l.known && !l.state.Reset.IsZero() && !now.Before(l.state.Reset) {
+			// The window has elapsed; refill until the next response tells us otherwise.
+			l.state.Remaining = l.state.Limit
+			l.state.Reset = time.Time{}
+		}
+		if !l.known || l.state.Remaining > 0 || l.state.Reset.IsZero() {
+			if l.known && l.state.Remaining > 0 {
+				l.state.Remaining--
+			}
+			l.mu.Unlock()
+			return nil
+		}
+		wait := l.state.Reset.Sub(now)
+		l.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// RateLimit returns the most recent rate limit budget reported by OneLogin.
+// It reports false until a response carrying the X-RateLimit-* headers has been received.
+func (c *Client) RateLimit() (RateLimit, bool) {
+	return c.limiter.current()
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how the client retries requests that fail with a transient error.
+type RetryPolicy struct {
+	MaxAttempts       int           // Total number of attempts, including the first one
+	BaseDelay         time.Duration // Delay before the first retry, doubled on every subsequent retry
+	MaxDelay          time.Duration // Upper bound for a single backoff delay
+	Jitter            float64       // Fraction (0-1) of each delay that is randomized
+	RetryableStatuses map[int]bool  // HTTP status codes that trigger a retry
+	RespectRetryAfter bool          // Wait for the duration given by a Retry-After header when present
+
+	// RetryNonIdempotent also retries POST and PATCH requests after transport errors and retryable
+	// statuses. These failures can happen after the server applied the request, so a retry may
+	// create a duplicate. A 429 with a Retry-After header is retried for every method regardless.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: map[int]bool{
+			http.StatusTooManyRequests:    true,
+			http.StatusBadGateway:         true,
+			http.StatusServiceUnavailable: true,
+			http.StatusGatewayTimeout:     true,
+		},
+		RespectRetryAfter: true,
+	}
+}
+
+// canRetry reports whether another attempt is allowed after the given zero-based attempt.
+func (p *RetryPolicy) canRetry(attempt int) bool {
+	return p != nil && attempt+1 < p.MaxAttempts
+}
+
+// retryableStatus reports whether the status code is configured as retryable.
+func (p *RetryPolicy) retryableStatus(code int) bool {
+	return p != nil && p.RetryableStatuses[code]
+}
+
+// retryableMethod reports whether requests with the given method may be retried after a failure
+// that the server may already have applied.
+func (p *RetryPolicy) retryableMethod(method string) bool {
+	if p == nil {
+		return false
+	}
+	if p.RetryNonIdempotent {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryableResponse reports whether a request with the given method should be retried after resp.
+// A 429 with a Retry-After header was rejected before processing, so it is safe for every method.
+func (p *RetryPolicy) retryableResponse(method string, resp *http.Response) bool {
+	if !p.retryableStatus(resp.StatusCode) {
+		return false
+	}
+	if resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get("Retry-After") != "" {
+		return true
+	}
+	return p.retryableMethod(method)
+}
+
+// backoff returns the delay to wait after the given zero-based attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 0; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		spread := float64(delay) * jitter
+		delay = time.Duration(float64(delay) - spread + rand.Float64()*spread)
+	}
+	return delay
+}
+
+// delay returns how long to wait before retrying after resp, preferring the Retry-After header when allowed.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if p.RespectRetryAfter && resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// isRetryableError reports whether a transport error is transient, such as a connection reset.
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// drainBody discards and closes a response body so the underlying connection can be reused.
+func drainBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+)
+
+// Attribute keys set on spans and metrics.
+const (
+	AttrOperation          string = "onelogin.operation"
+	AttrSubdomain          string = "onelogin.subdomain"
+	AttrRetries            string = "onelogin.retries"
+	AttrRateLimitRemaining string = "onelogin.rate_limit.remaining"
+	AttrRequestID          string = "onelogin.request_id"
+	AttrMethod             string = "http.request.method"
+	AttrPath               string = "url.path"
+	AttrStatusCode         string = "http.response.status_code"
+)
+
+// Metric names recorded for every API call.
+const (
+	MetricRequests string = "onelogin.client.requests" // Counter of API calls
+	MetricErrors   string = "onelogin.client.errors"   // Counter of API calls failing with a transport error or a non-2xx status
+	MetricRetries  string = "onelogin.client.retries"  // Counter of retried attempts
+	MetricDuration string = "onelogin.client.duration" // Histogram of API call durations in seconds, retries included
+)
+
+// Attribute is a key/value pair describing a span or a measurement.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Tracer starts a span for every API call. It is usually an adapter around an OpenTelemetry tracer.
+type Tracer interface {
+	// Start starts a span named after the operation, e.g. "users.create", as a child of any span in ctx,
+	// and returns a context carrying it.
+	Start(ctx context.Context, operation string) (context.Context, Span)
+}
+
+// Span is a traced API call.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	// RecordError marks the span as failed. API calls answered with a non-2xx status are recorded
+	// with an *olerror.APIError.
+	RecordError(err error)
+	End()
+}
+
+// Metrics receives the counters and histograms of API calls. It is usually an adapter around an
+// OpenTelemetry meter.
+type Metrics interface {
+	// Add increments the counter name by value.
+	Add(ctx context.Context, name string, value int64, attrs ...Attribute)
+	// Record records value in the histogram name.
+	Record(ctx context.Context, name string, value float64, attrs ...Attribute)
+}
+
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, _ string) (context.Context, Span) { return ctx, nopSpan{} }
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...Attribute) {}
+func (nopSpan) RecordError(error)          {}
+func (nopSpan) End()                       {}
+
+type nopMetrics struct{}
+
+func (nopMetrics) Add(context.Context, string, int64, ...Attribute)      {}
+func (nopMetrics) Record(context.Context, string, float64, ...Attribute) {}
+
+type operationKey struct{}
+
+// WithOperation returns a context naming the operation of the API calls made with it, overriding
+// the name derived from their method and path.
+func WithOperation(ctx context.Context, operation string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation)
+}
+
+// idSegment matches the path segments identifying a resource: numbers and UUIDs.
+var idSegment = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F-]{27})$`)
+
+// apiVersionPrefix matches the /api/<version> prefix of API paths.
+var apiVersionPrefix = regexp.MustCompile(`^/api/[0-9]+/`)
+
+// OperationName derives the name of an API call from its method and path: the resource names of
+// the path joined by dots, followed by the action, e.g.
+//
+//	POST   /api/2/users          users.create
+//	GET    /api/2/roles          roles.list
+//	GET    /api/2/users/42/apps  users.apps.list
+//	PUT    /api/2/apps/7         apps.update
+func OperationName(method, path string) string {
+	path = apiVersionPrefix.ReplaceAllString(path, "/")
+
+	var names []string
+	lastIsID := false
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		if lastIsID = idSegment.MatchString(segment); !lastIsID {
+			names = append(names, strings.ReplaceAll(segment, "-", "_"))
+		}
+	}
+
+	var action string
+	switch method {
+	case http.MethodGet:
+		action = "list"
+		if lastIsID {
+			action = "get"
+		}
+	case http.MethodPost:
+		action = "create"
+	case http.MethodPut, http.MethodPatch:
+		action = "update"
+	case http.MethodDelete:
+		action = "delete"
+	default:
+		action = strings.ToLower(method)
+	}
+	return strings.Join(append(names, action), ".")
+}
+
+type callStatsKey struct{}
+
+// callStats counts the retries of an API call for the telemetry layer.
+type callStats struct {
+	retries int
+}
+
+// countRetry records a retry in the call stats of ctx, if any.
+func countRetry(ctx context.Context) {
+	if s, ok := ctx.Value(callStatsKey{}).(*callStats); ok {
+		s.retries++
+	}
+}
+
+// subdomain returns the tenant subdomain reported in telemetry, falling back to the first label
+// of the OneLogin domain.
+func (c *Client) subdomain() string {
+	if c.Subdomain != "" {
+		return c.Subdomain
+	}
+	u, err := url.Parse(c.OLdomain)
+	if err != nil {
+		return ""
+	}
+	host := u.Hostname()
+	if i := strings.Index(host, "."); i > 0 && host[:i] != "api" {
+		return host[:i]
+	}
+	return ""
+}
+
+// telemetryLayer traces every API call and records its metrics. It is a no-op unless c.Tracer or
+// c.Metrics is set.
+func (c *Client) telemetryLayer(next RoundTripFunc) RoundTripFunc {
+	if c.Tracer == nil && c.Metrics == nil {
+		return next
+	}
+	var tracer Tracer = nopTracer{}
+	if c.Tracer != nil {
+		tracer = c.Tracer
+	}
+	var metrics Metrics = nopMetrics{}
+	if c.Metrics != nil {
+		metrics = c.Metrics
+	}
+
+	return func(req *http.Request) (*http.Response, error) {
+		operation, _ := req.Context().Value(operationKey{}).(string)
+		if operation == "" {
+			operation = OperationName(req.Method, req.URL.Path)
+		}
+		ctx, span := tracer.Start(req.Context(), operation)
+		defer span.End()
+		stats := &callStats{}
+		req = req.WithContext(context.WithValue(ctx, callStatsKey{}, stats))
+
+		attrs := []Attribute{
+			{Key: AttrOperation, Value: operation},
+			{Key: AttrSubdomain, Value: c.subdomain()},
+			{Key: AttrMethod, Value: req.Method},
+		}
+		span.SetAttributes(append(attrs, Attribute{Key: AttrPath, Value: req.URL.Path})...)
+
+		start := time.Now()
+		resp, err := next(req)
+		elapsed := time.Since(start)
+
+		span.SetAttributes(Attribute{Key: AttrRetries, Value: stats.retries})
+		if err != nil {
+			span.RecordError(err)
+		} else {
+			attrs = append(attrs, Attribute{Key: AttrStatusCode, Value: resp.StatusCode})
+			span.SetAttributes(
+				Attribute{Key: AttrStatusCode, Value: resp.StatusCode},
+				Attribute{Key: AttrRequestID, Value: resp.Header.Get(RequestIDHeader)},
+			)
+			if rl, ok := ParseRateLimit(resp.Header, start); ok {
+				span.SetAttributes(Attribute{Key: AttrRateLimitRemaining, Value: rl.Remaining})
+			}
+			if resp.StatusCode < 200 || resp.StatusCode > 299 {
+				span.RecordError(&olerror.APIError{
+					Message:    http.StatusText(resp.StatusCode),
+					Code:       resp.StatusCode,
+					StatusCode: resp.StatusCode,
+					Method:     req.Method,
+					Path:       req.URL.Path,
+					RequestID:  resp.Header.Get(RequestIDHeader),
+				})
+			}
+		}
+
+		metrics.Add(ctx, MetricRequests, 1, attrs...)
+		if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+			metrics.Add(ctx, MetricErrors, 1, attrs...)
+		}
+		if stats.retries > 0 {
+			metrics.Add(ctx, MetricRetries, int64(stats.retries), attrs...)
+		}
+		metrics.Record(ctx, MetricDuration, elapsed.Seconds(), attrs...)
+		return resp, err
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordedSpan is a span captured by a MemoryTelemetry.
+type RecordedSpan struct {
+	Name       string
+	Attributes map[string]interface{}
+	Errors     []error
+	Ended      bool
+}
+
+// MemoryTelemetry is a Tracer and Metrics keeping every span and measurement in memory, for tests.
+// It is safe for concurrent use.
+type MemoryTelemetry struct {
+	mu         sync.Mutex
+	spans      []*RecordedSpan
+	counters   map[string]int64
+	histograms map[string][]float64
+}
+
+// NewMemoryTelemetry returns an empty MemoryTelemetry.
+func NewMemoryTelemetry() *MemoryTelemetry {
+	return &MemoryTelemetry{
+		counters:   map[string]int64{},
+		histograms: map[string][]float64{},
+	}
+}
+
+func (m *MemoryTelemetry) Start(ctx context.Context, operation string) (context.Context, Span) {
+	span := &RecordedSpan{Name: operation, Attributes: map[string]interface{}{}}
+	m.mu.Lock()
+	m.spans = append(m.spans, span)
+	m.mu.Unlock()
+	return ctx, &memorySpan{m: m, span: span}
+}
+
+func (m *MemoryTelemetry) Add(_ context.Context, name string, value int64, _ ...Attribute) {
+	m.mu.Lock()
+	m.counters[name] += value
+	m.mu.Unlock()
+}
+
+func (m *MemoryTelemetry) Record(_ context.Context, name string, value float64, _ ...Attribute) {
+	m.mu.Lock()
+	m.histograms[name] = append(m.histograms[name], value)
+	m.mu.Unlock()
+}
+
+// Spans returns a copy of the spans started so far.
+func (m *MemoryTelemetry) Spans() []RecordedSpan {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	spans := make([]RecordedSpan, len(m.spans))
+	for i, s := range m.spans {
+		spans[i] = *s
+		spans[i].Attributes = make(map[string]interface{}, len(s.Attributes))
+		for k, v := range s.Attributes {
+			spans[i].Attributes[k] = v
+		}
+		spans[i].Errors = append([]error(nil), s.Errors...)
+	}
+	return spans
+}
+
+// Counter returns the total of the counter name.
+func (m *MemoryTelemetry) Counter(name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counters[name]
+}
+
+// Histogram returns the values recorded in the histogram name.
+func (m *MemoryTelemetry) Histogram(name string) []float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]float64(nil), m.histograms[name]...)
+}
+
+type memorySpan struct {
+	m    *MemoryTelemetry
+	span *RecordedSpan
+}
+
+func (s *memorySpan) SetAttributes(attrs ...Attribute) {
+	s.m.mu.Lock()
+	for _, a := range attrs {
+		s.span.Attributes[a.Key] = a.Value
+	}
+	s.m.mu.Unlock()
+}
+
+func (s *memorySpan) RecordError(err error) {
+	s.m.mu.Lock()
+	s.span.Errors = append(s.span.Errors, err)
+	s.m.mu.Unlock()
+}
+
+func (s *memorySpan) End() {
+	s.m.mu.Lock()
+	s.span.Ended = true
+	s.m.mu.Unlock()
+}
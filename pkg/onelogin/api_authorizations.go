@@ -1,6 +1,10 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -9,211 +13,228 @@ const (
 	APIAuthPath string = "api/2/api_authorizations"
 )
 
-func (sdk *OneloginSDK) CreateAuthServer(authServer *mod.AuthServer) (interface{}, error) {
+func (sdk *OneloginSDK) CreateAuthServer(authServer *mod.AuthServer) (*mod.AuthServer, error) {
+	return sdk.CreateAuthServerWithContext(context.Background(), authServer)
+}
+
+func (sdk *OneloginSDK) CreateAuthServerWithContext(ctx context.Context, authServer *mod.AuthServer) (*mod.AuthServer, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, authServer)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*mod.AuthServer](ctx, sdk.Client, http.MethodPost, p, nil, authServer)
+	return created, err
 }
 
 // was ListAuthServers
-func (sdk *OneloginSDK) GetAuthServers(queryParams mod.Queryable) (interface{}, error) {
-	p, err := utl.BuildAPIPath(APIAuthPath)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+func (sdk *OneloginSDK) GetAuthServers(queryParams mod.Queryable) ([]mod.AuthServer, error) {
+	return sdk.GetAuthServersWithContext(context.Background(), queryParams)
 }
 
-func (sdk *OneloginSDK) GetAuthServerByID(id int, queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetAuthServersWithContext(ctx context.Context, queryParams mod.Queryable) ([]mod.AuthServer, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	authServers, _, err := api.Do[[]mod.AuthServer](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return authServers, err
 }
 
-func (sdk *OneloginSDK) UpdateAuthServer(id int, authServer mod.AuthServer) (interface{}, error) {
-	p, err := utl.BuildAPIPath(APIAuthPath)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, authServer)
+func (sdk *OneloginSDK) GetAuthServerByID(id int, queryParams mod.Queryable) (*mod.AuthServer, error) {
+	return sdk.GetAuthServerByIDWithContext(context.Background(), id, queryParams)
+}
+
+func (sdk *OneloginSDK) GetAuthServerByIDWithContext(ctx context.Context, id int, queryParams mod.Queryable) (*mod.AuthServer, error) {
+	p, err := utl.BuildAPIPath(APIAuthPath, id)
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	authServer, _, err := api.Do[*mod.AuthServer](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return authServer, err
 }
 
-func (sdk *OneloginSDK) DeleteAuthServer(id int) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateAuthServer(id int, authServer mod.AuthServer) (*mod.AuthServer, error) {
+	return sdk.UpdateAuthServerWithContext(context.Background(), id, authServer)
+}
+
+func (sdk *OneloginSDK) UpdateAuthServerWithContext(ctx context.Context, id int, authServer mod.AuthServer) (*mod.AuthServer, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Delete(&p)
+	updated, _, err := api.Do[*mod.AuthServer](ctx, sdk.Client, http.MethodPut, p, nil, authServer)
+	return updated, err
+}
+
+func (sdk *OneloginSDK) DeleteAuthServer(id int) error {
+	return sdk.DeleteAuthServerWithContext(context.Background(), id)
+}
+
+func (sdk *OneloginSDK) DeleteAuthServerWithContext(ctx context.Context, id int) error {
+	p, err := utl.BuildAPIPath(APIAuthPath, id)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
 
 // Claim related endpoints
-func (sdk *OneloginSDK) CreateAuthServerClaim(id int, claim mod.AccessTokenClaim) (interface{}, error) {
+func (sdk *OneloginSDK) CreateAuthServerClaim(id int, claim mod.AccessTokenClaim) (*mod.AccessTokenClaim, error) {
+	return sdk.CreateAuthServerClaimWithContext(context.Background(), id, claim)
+}
+
+func (sdk *OneloginSDK) CreateAuthServerClaimWithContext(ctx context.Context, id int, claim mod.AccessTokenClaim) (*mod.AccessTokenClaim, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "claims")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, claim)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*mod.AccessTokenClaim](ctx, sdk.Client, http.MethodPost, p, nil, claim)
+	return created, err
 }
 
-func (sdk *OneloginSDK) DeleteAuthClaim(id, claimID int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteAuthClaim(id, claimID int) error {
+	return sdk.DeleteAuthClaimWithContext(context.Background(), id, claimID)
+}
+
+func (sdk *OneloginSDK) DeleteAuthClaimWithContext(ctx context.Context, id, claimID int) error {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "claims", claimID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) GetAuthClaims(id int, queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetAuthClaims(id int, queryParams mod.Queryable) ([]mod.AccessTokenClaim, error) {
+	return sdk.GetAuthClaimsWithContext(context.Background(), id, queryParams)
+}
+
+func (sdk *OneloginSDK) GetAuthClaimsWithContext(ctx context.Context, id int, queryParams mod.Queryable) ([]mod.AccessTokenClaim, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "claims")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	accessTokenClaims, _, err := api.Do[[]mod.AccessTokenClaim](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return accessTokenClaims, err
 }
 
-func (sdk *OneloginSDK) UpdateClaim(id, claimID int, claim mod.AccessTokenClaim) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateClaim(id, claimID int, claim mod.AccessTokenClaim) (*mod.AccessTokenClaim, error) {
+	return sdk.UpdateClaimWithContext(context.Background(), id, claimID, claim)
+}
+
+func (sdk *OneloginSDK) UpdateClaimWithContext(ctx context.Context, id, claimID int, claim mod.AccessTokenClaim) (*mod.AccessTokenClaim, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "claims", claimID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, claim)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*mod.AccessTokenClaim](ctx, sdk.Client, http.MethodPut, p, nil, claim)
+	return updated, err
 }
 
 // Scopes related endpoints
-func (sdk *OneloginSDK) CreateAuthServerScope(id int, scope mod.Scope) (interface{}, error) {
+func (sdk *OneloginSDK) CreateAuthServerScope(id int, scope mod.Scope) (*mod.Scope, error) {
+	return sdk.CreateAuthServerScopeWithContext(context.Background(), id, scope)
+}
+
+func (sdk *OneloginSDK) CreateAuthServerScopeWithContext(ctx context.Context, id int, scope mod.Scope) (*mod.Scope, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "scopes")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, scope)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*mod.Scope](ctx, sdk.Client, http.MethodPost, p, nil, scope)
+	return created, err
+}
+
+func (sdk *OneloginSDK) DeleteAuthServerScope(id, scopeID int) error {
+	return sdk.DeleteAuthServerScopeWithContext(context.Background(), id, scopeID)
 }
 
-func (sdk *OneloginSDK) DeleteAuthServerScope(id, scopeID int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteAuthServerScopeWithContext(ctx context.Context, id, scopeID int) error {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "scopes", scopeID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+func (sdk *OneloginSDK) GetAuthServerScopes(id int, queryParams mod.Queryable) ([]mod.Scope, error) {
+	return sdk.GetAuthServerScopesWithContext(context.Background(), id, queryParams)
 }
 
-func (sdk *OneloginSDK) GetAuthServerScopes(id int, queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetAuthServerScopesWithContext(ctx context.Context, id int, queryParams mod.Queryable) ([]mod.Scope, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "scopes")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	scopes, _, err := api.Do[[]mod.Scope](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return scopes, err
 }
 
-func (sdk *OneloginSDK) UpdateAuthServerScope(id, scopeID int, scope mod.Scope) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateAuthServerScope(id, scopeID int, scope mod.Scope) (*mod.Scope, error) {
+	return sdk.UpdateAuthServerScopeWithContext(context.Background(), id, scopeID, scope)
+}
+
+func (sdk *OneloginSDK) UpdateAuthServerScopeWithContext(ctx context.Context, id, scopeID int, scope mod.Scope) (*mod.Scope, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "scopes", scopeID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, scope)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*mod.Scope](ctx, sdk.Client, http.MethodPut, p, nil, scope)
+	return updated, err
 }
 
 // Client App related endpoints
 
-func (sdk *OneloginSDK) CreateClientApp(id int, clientApp mod.ClientApp) (interface{}, error) {
+func (sdk *OneloginSDK) CreateClientApp(id int, clientApp mod.ClientApp) (*mod.ClientApp, error) {
+	return sdk.CreateClientAppWithContext(context.Background(), id, clientApp)
+}
+
+func (sdk *OneloginSDK) CreateClientAppWithContext(ctx context.Context, id int, clientApp mod.ClientApp) (*mod.ClientApp, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "clients")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, clientApp)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*mod.ClientApp](ctx, sdk.Client, http.MethodPost, p, nil, clientApp)
+	return created, err
+}
+
+func (sdk *OneloginSDK) GetClientApps(id int) ([]mod.ClientApp, error) {
+	return sdk.GetClientAppsWithContext(context.Background(), id)
 }
 
-func (sdk *OneloginSDK) GetClientApps(id int) (interface{}, error) {
+func (sdk *OneloginSDK) GetClientAppsWithContext(ctx context.Context, id int) ([]mod.ClientApp, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "clients")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	clientApps, _, err := api.Do[[]mod.ClientApp](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return clientApps, err
 }
 
-func (sdk *OneloginSDK) DeleteClientApp(id, clientID int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteClientApp(id, clientID int) error {
+	return sdk.DeleteClientAppWithContext(context.Background(), id, clientID)
+}
+
+func (sdk *OneloginSDK) DeleteClientAppWithContext(ctx context.Context, id, clientID int) error {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "clients", clientID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) UpdateClientApp(id, clientID int, clientApp mod.ClientApp) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateClientApp(id, clientID int, clientApp mod.ClientApp) (*mod.ClientApp, error) {
+	return sdk.UpdateClientAppWithContext(context.Background(), id, clientID, clientApp)
+}
+
+func (sdk *OneloginSDK) UpdateClientAppWithContext(ctx context.Context, id, clientID int, clientApp mod.ClientApp) (*mod.ClientApp, error) {
 	p, err := utl.BuildAPIPath(APIAuthPath, id, "clients", clientID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, clientApp)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*mod.ClientApp](ctx, sdk.Client, http.MethodPut, p, nil, clientApp)
+	return updated, err
 }
@@ -1,6 +1,10 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -9,143 +13,147 @@ const (
 	AppPath string = "api/2/apps"
 )
 
-func (sdk *OneloginSDK) CreateApp(app mod.App) (interface{}, error) {
+func (sdk *OneloginSDK) CreateApp(app mod.App) (*mod.App, error) {
+	return sdk.CreateAppWithContext(context.Background(), app)
+}
+
+func (sdk *OneloginSDK) CreateAppWithContext(ctx context.Context, app mod.App) (*mod.App, error) {
 	p, err := utl.BuildAPIPath(AppPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, app)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
-
+	created, _, err := api.Do[*mod.App](ctx, sdk.Client, http.MethodPost, p, nil, app)
+	return created, err
 }
 
 // was ListApps
-func (sdk *OneloginSDK) GetApps(queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetApps(queryParams mod.Queryable) ([]mod.App, error) {
+	return sdk.GetAppsWithContext(context.Background(), queryParams)
+}
+
+func (sdk *OneloginSDK) GetAppsWithContext(ctx context.Context, queryParams mod.Queryable) ([]mod.App, error) {
 	p, err := utl.BuildAPIPath(AppPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	apps, _, err := api.Do[[]mod.App](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return apps, err
 }
 
-func (sdk *OneloginSDK) GetAppByID(id int, queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetAppByID(id int, queryParams mod.Queryable) (*mod.App, error) {
+	return sdk.GetAppByIDWithContext(context.Background(), id, queryParams)
+}
+
+func (sdk *OneloginSDK) GetAppByIDWithContext(ctx context.Context, id int, queryParams mod.Queryable) (*mod.App, error) {
 	p, err := utl.BuildAPIPath(AppPath, id)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	app, _, err := api.Do[*mod.App](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return app, err
+}
 
+func (sdk *OneloginSDK) UpdateApp(id int, app mod.App) (*mod.App, error) {
+	return sdk.UpdateAppWithContext(context.Background(), id, app)
 }
 
-func (sdk *OneloginSDK) UpdateApp(id int, app mod.App) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateAppWithContext(ctx context.Context, id int, app mod.App) (*mod.App, error) {
 	p, err := utl.BuildAPIPath(AppPath, id)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, app)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*mod.App](ctx, sdk.Client, http.MethodPut, p, nil, app)
+	return updated, err
+}
 
+func (sdk *OneloginSDK) DeleteApp(id int) error {
+	return sdk.DeleteAppWithContext(context.Background(), id)
 }
 
-func (sdk *OneloginSDK) DeleteApp(id int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteAppWithContext(ctx context.Context, id int) error {
 	p, err := utl.BuildAPIPath(AppPath, id)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 
 }
 
-func (sdk *OneloginSDK) CreateAppRule(id int, appRule mod.AppRule) (interface{}, error) {
-	p, err := utl.BuildAPIPath(AppPath, id)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Post(&p, appRule)
+func (sdk *OneloginSDK) CreateAppRule(id int, appRule mod.AppRule) (*mod.AppRule, error) {
+	return sdk.CreateAppRuleWithContext(context.Background(), id, appRule)
+}
+
+func (sdk *OneloginSDK) CreateAppRuleWithContext(ctx context.Context, id int, appRule mod.AppRule) (*mod.AppRule, error) {
+	p, err := utl.BuildAPIPath(AppPath, id, "rules")
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*mod.AppRule](ctx, sdk.Client, http.MethodPost, p, nil, appRule)
+	return created, err
+}
 
+func (sdk *OneloginSDK) GetAppRules(id int, queryParams mod.Queryable) ([]mod.AppRule, error) {
+	return sdk.GetAppRulesWithContext(context.Background(), id, queryParams)
 }
 
-func (sdk *OneloginSDK) GetAppRules(id int, queryParams mod.Queryable) (interface{}, error) {
-	p, err := utl.BuildAPIPath(AppPath, id)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Get(&p, queryParams)
+func (sdk *OneloginSDK) GetAppRulesWithContext(ctx context.Context, id int, queryParams mod.Queryable) ([]mod.AppRule, error) {
+	p, err := utl.BuildAPIPath(AppPath, id, "rules")
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	appRules, _, err := api.Do[[]mod.AppRule](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return appRules, err
+}
 
+func (sdk *OneloginSDK) GetAppRuleByID(id, ruleID int, queryParams mod.Queryable) (*mod.AppRule, error) {
+	return sdk.GetAppRuleByIDWithContext(context.Background(), id, ruleID, queryParams)
 }
 
-func (sdk *OneloginSDK) GetAppRuleByID(id, ruleID int, queryParams mod.Queryable) (interface{}, error) {
-	p, err := utl.BuildAPIPath(AppPath, id)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Get(&p, queryParams)
+func (sdk *OneloginSDK) GetAppRuleByIDWithContext(ctx context.Context, id, ruleID int, queryParams mod.Queryable) (*mod.AppRule, error) {
+	p, err := utl.BuildAPIPath(AppPath, id, "rules", ruleID)
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	appRule, _, err := api.Do[*mod.AppRule](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return appRule, err
+}
 
+func (sdk *OneloginSDK) UpdateAppRule(id, ruleID int, appRule mod.AppRule, queryParams map[string]string) (*mod.AppRule, error) {
+	return sdk.UpdateAppRuleWithContext(context.Background(), id, ruleID, appRule, queryParams)
 }
 
-func (sdk *OneloginSDK) UpdateAppRule(id, ruleID int, appRule mod.AppRule, queryParams map[string]string) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateAppRuleWithContext(ctx context.Context, id, ruleID int, appRule mod.AppRule, queryParams map[string]string) (*mod.AppRule, error) {
 	p, err := utl.BuildAPIPath(AppPath, id, "rules", ruleID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, appRule)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*mod.AppRule](ctx, sdk.Client, http.MethodPut, p, nil, appRule)
+	return updated, err
+}
 
+func (sdk *OneloginSDK) DeleteAppRule(id, ruleID int, queryParams map[string]string) error {
+	return sdk.DeleteAppRuleWithContext(context.Background(), id, ruleID, queryParams)
 }
 
-func (sdk *OneloginSDK) DeleteAppRule(id, ruleID int, queryParams map[string]string) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteAppRuleWithContext(ctx context.Context, id, ruleID int, queryParams map[string]string) error {
 	p, err := utl.BuildAPIPath(AppPath, id, "rules", ruleID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+func (sdk *OneloginSDK) GetAppUsers(appID int) ([]mod.User, error) {
+	return sdk.GetAppUsersWithContext(context.Background(), appID)
 }
 
-func (sdk *OneloginSDK) GetAppUsers(appID int) (interface{}, error) {
+func (sdk *OneloginSDK) GetAppUsersWithContext(ctx context.Context, appID int) ([]mod.User, error) {
 	p, err := utl.BuildAPIPath(AppPath, appID, "users")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	users, _, err := api.Do[[]mod.User](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return users, err
 }
@@ -1,13 +1,15 @@
 package authentication
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	olError "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
 )
@@ -17,29 +19,163 @@ const (
 	RevokePath string = "/auth/oauth2/revoke"
 )
 
+// DefaultTokenTimeout bounds a token request shared by concurrent callers.
+const DefaultTokenTimeout = 30 * time.Second
+
+// HTTPClient is an interface that defines the Do method for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Config holds the credentials and transport used by an Authenticator.
+type Config struct {
+	Subdomain    string        // OneLogin subdomain, used to derive BaseURL when it is empty
+	BaseURL      string        // API base URL hosting the token and revoke endpoints
+	TokenURL     string        // Token endpoint URL; BaseURL + TkPath when empty
+	ClientID     string        // API credential client ID
+	ClientSecret string        // API credential client secret
+	HTTPClient   HTTPClient    // HTTP client for token requests; http.DefaultClient when nil
+	RefreshSkew  time.Duration // Refresh tokens this long before expiry; DefaultRefreshSkew when zero
+	TokenTimeout time.Duration // Bound for a token request; DefaultTokenTimeout when zero
+}
+
+// Authenticator obtains and caches access tokens using the client credentials grant.
+// It is safe for concurrent use by multiple goroutines.
 type Authenticator struct {
-	accessToken string
-	subdomain   string
+	mu           sync.Mutex
+	token        *Token
+	inflight     *refreshCall
+	refreshSkew  time.Duration
+	tokenTimeout time.Duration
+
+	subdomain    string
+	baseURL      string
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	httpClient   HTTPClient
 }
 
+// NewAuthenticator creates an Authenticator for subdomain using the
+// ONELOGIN_CLIENT_ID and ONELOGIN_CLIENT_SECRET environment variables.
+// The variables are read once, when the Authenticator is created.
 func NewAuthenticator(subdomain string) *Authenticator {
-	return &Authenticator{subdomain: subdomain}
+	return NewAuthenticatorWithConfig(Config{
+		Subdomain:    subdomain,
+		ClientID:     os.Getenv("ONELOGIN_CLIENT_ID"),
+		ClientSecret: os.Getenv("ONELOGIN_CLIENT_SECRET"),
+	})
+}
+
+// NewAuthenticatorWithConfig creates an Authenticator holding explicit credentials.
+func NewAuthenticatorWithConfig(cfg Config) *Authenticator {
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s.onelogin.com", cfg.Subdomain)
+	}
+	tokenURL := cfg.TokenURL
+	if tokenURL == "" {
+		tokenURL = baseURL + TkPath
+	}
+	refreshSkew := cfg.RefreshSkew
+	if refreshSkew <= 0 {
+		refreshSkew = DefaultRefreshSkew
+	}
+	tokenTimeout := cfg.TokenTimeout
+	if tokenTimeout <= 0 {
+		tokenTimeout = DefaultTokenTimeout
+	}
+	return &Authenticator{
+		subdomain:    cfg.Subdomain,
+		baseURL:      baseURL,
+		tokenURL:     tokenURL,
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		httpClient:   cfg.HTTPClient,
+		refreshSkew:  refreshSkew,
+		tokenTimeout: tokenTimeout,
+	}
+}
+
+// refreshCall tracks a token request shared by concurrent callers.
+type refreshCall struct {
+	done  chan struct{}
+	token *Token
+	err   error
+}
+
+// client returns the HTTP client used for token requests.
+func (a *Authenticator) client() HTTPClient {
+	if a.httpClient == nil {
+		return http.DefaultClient
+	}
+	return a.httpClient
 }
 
 func (a *Authenticator) GenerateToken() error {
-	// Read & Check environment variables
-	clientID := os.Getenv("ONELOGIN_CLIENT_ID")
-	if len(clientID) == 0 {
-		return olError.NewAuthenticationError("Missing ONELOGIN_CLIENT_ID Env Variable")
+	return a.GenerateTokenWithContext(context.Background())
+}
+
+// GenerateTokenWithContext requests a new access token, returning early when ctx is done.
+// Concurrent calls share a single request to the token endpoint, which runs on its own context
+// bounded by the token timeout, so a caller giving up does not fail the others.
+func (a *Authenticator) GenerateTokenWithContext(ctx context.Context) error {
+	_, err := a.generate(ctx)
+	return err
+}
+
+// generate joins or starts the shared token request and returns the token it fetched, which
+// stays valid for the caller even if the cached token is revoked in the meantime.
+func (a *Authenticator) generate(ctx context.Context) (*Token, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	a.mu.Lock()
+	call := a.inflight
+	if call == nil {
+		call = &refreshCall{done: make(chan struct{})}
+		a.inflight = call
+		go a.refresh(call)
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// refresh performs the shared token request of call and stores the new token.
+func (a *Authenticator) refresh(call *refreshCall) {
+	ctx, cancel := context.WithTimeout(context.Background(), a.tokenTimeout)
+	defer cancel()
+	tk, err := a.requestToken(ctx)
+
+	a.mu.Lock()
+	if err == nil {
+		a.token = tk
+	}
+	a.inflight = nil
+	a.mu.Unlock()
+
+	call.token, call.err = tk, err
+	close(call.done)
+}
+
+// requestToken performs the client credentials grant against the token endpoint.
+func (a *Authenticator) requestToken(ctx context.Context) (*Token, error) {
+	// Check credentials
+	if len(a.clientID) == 0 {
+		return nil, olError.NewAuthenticationError("Missing client ID (ONELOGIN_CLIENT_ID)")
 	}
-	//fmt.Println("clientID", clientID)
-	clientSecret := os.Getenv("ONELOGIN_CLIENT_SECRET")
-	if len(clientSecret) == 0 {
-		return olError.NewAuthenticationError("Missing ONELOGIN_CLIENT_SECRET Env Variable")
+	if len(a.clientSecret) == 0 {
+		return nil, olError.NewAuthenticationError("Missing client secret (ONELOGIN_CLIENT_SECRET)")
 	}
 
 	// Construct the authentication URL
-	authURL := fmt.Sprintf("https://%s.onelogin.com%s", a.subdomain, TkPath)
+	authURL := a.tokenURL
 
 	// Create authentication request payload
 	data := map[string]string{
@@ -49,62 +185,63 @@ func (a *Authenticator) GenerateToken() error {
 	// Convert payload to JSON
 	jsonData, err := json.Marshal(data)
 	if err != nil {
-		return olError.NewSerializationError("Unable to convert payload to JSON")
+		return nil, olError.WrapSerializationError("Unable to convert payload to JSON", err)
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest(http.MethodPost, authURL, strings.NewReader(string(jsonData)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL, strings.NewReader(string(jsonData)))
 	if err != nil {
-		return olError.NewRequestError("Failed to create authentication request")
+		return nil, olError.WrapRequestError("Failed to create authentication request", err)
 	}
 
 	// Add authorization header with base64-encoded credentials
-	encodedCredentials := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", clientID, clientSecret)))
+	encodedCredentials := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", a.clientID, a.clientSecret)))
 	req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encodedCredentials))
 	req.Header.Add("Content-Type", "application/json")
 
 	// Send the HTTP request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := a.client().Do(req)
 	if err != nil {
-		return olError.NewRequestError("Failed to send authentication request")
+		return nil, olError.WrapRequestError("Failed to send authentication request", err)
+	}
+	defer resp.Body.Close()
+
+	// Check if authentication failed
+	if resp.StatusCode != http.StatusOK {
+		return nil, olError.WrapAuthenticationError("Authentication failed", olError.NewAPIErrorFromResponse(resp))
 	}
 
 	// Parse the authentication response
-	var result map[string]interface{}
+	var result tokenResponse
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	if err != nil {
-		return olError.NewSerializationError("Failed to read authentication response")
-	}
-
-	// Check if authentication failed
-	if resp.StatusCode != http.StatusOK {
-		return olError.NewAuthenticationError("Authentication failed")
+		return nil, olError.WrapSerializationError("Failed to read authentication response", err)
 	}
 
 	// Extract access token from the response
-	accessToken, ok := result["access_token"].(string)
-	if !ok {
-		return olError.NewAuthenticationError("Authentication Failed at Endpoint")
+	if result.AccessToken == "" {
+		return nil, olError.NewAuthenticationError("Authentication Failed at Endpoint")
 	}
-	// Store access token
-	a.accessToken = accessToken
 
-	return nil
+	return newToken(result, time.Now()), nil
 }
 
 func (a *Authenticator) RevokeToken(token *string) error {
-	// Read environment variables
-	clientID := os.Getenv("ONELOGIN_CLIENT_ID")
-	clientSecret := os.Getenv("ONELOGIN_CLIENT_SECRET")
+	return a.RevokeTokenWithContext(context.Background(), token)
+}
 
-	// Check if required environment variables are missing
-	if clientID == "" || clientSecret == "" {
-		return errors.New("missing client ID, client secret, or subdomain")
+// RevokeTokenWithContext revokes the given access token, aborting the request when ctx is done.
+func (a *Authenticator) RevokeTokenWithContext(ctx context.Context, token *string) error {
+	// Check if required credentials are missing
+	if a.clientID == "" || a.clientSecret == "" {
+		return olError.NewAuthenticationError("Missing client ID or client secret")
+	}
+	if token == nil || *token == "" {
+		return olError.NewAuthenticationError("Missing access token to revoke")
 	}
 
 	// Construct the revoke URL
-	revokeURL := fmt.Sprintf("%s.onelogin.com%s", a.subdomain, RevokePath)
+	revokeURL := a.baseURL + RevokePath
 
 	// Create revoke request payload
 	data := struct {
@@ -116,38 +253,102 @@ func (a *Authenticator) RevokeToken(token *string) error {
 	// Convert payload to JSON
 	jsonData, err := json.Marshal(data)
 	if err != nil {
-		return fmt.Errorf("failed to create revocation request: %w", err)
+		return olError.WrapAuthenticationError("Failed to create revocation request", err)
 	}
 
 	// Create HTTP request
-	req, err := http.NewRequest("POST", revokeURL, strings.NewReader(string(jsonData)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, revokeURL, strings.NewReader(string(jsonData)))
 	if err != nil {
-		return fmt.Errorf("failed to create revocation request: %w", err)
+		return olError.WrapAuthenticationError("Failed to create revocation request", err)
 	}
 
 	// Add authorization header with base64-encoded credentials
-	encodedCredentials := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", clientID, clientSecret)))
+	encodedCredentials := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", a.clientID, a.clientSecret)))
 	req.Header.Add("Authorization", fmt.Sprintf("Basic %s", encodedCredentials))
 	req.Header.Add("Content-Type", "application/json")
 
 	// Send the HTTP request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := a.client().Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to revoke: %w", err)
+		return olError.WrapAuthenticationError("Failed to send revocation request", err)
 	}
+	defer resp.Body.Close()
 
 	// Check if revocation failed
 	if resp.StatusCode != http.StatusOK {
-		return olError.NewAuthenticationError("Revocation failed")
+		return olError.WrapAuthenticationError(fmt.Sprintf("Revocation failed with status %d", resp.StatusCode), olError.NewAPIErrorFromResponse(resp))
 	}
 
-	// Success condition feedback
-	fmt.Println("Revocation successful")
-
 	return nil
 }
 
+// RevokeCurrentToken revokes the cached access token, if any, and clears it so that the
+// next request generates a new one.
+func (a *Authenticator) RevokeCurrentToken(ctx context.Context) error {
+	a.mu.Lock()
+	tk := a.token
+	a.token = nil
+	a.mu.Unlock()
+	if tk == nil {
+		return nil
+	}
+	return a.RevokeTokenWithContext(ctx, &tk.AccessToken)
+}
+
+// GetToken returns the current access token, refreshing it first when it is about to expire.
 func (a *Authenticator) GetToken() (string, error) {
-	return a.accessToken, nil
+	return a.GetTokenWithContext(context.Background())
+}
+
+// GetTokenWithContext returns the current access token, refreshing it first when it expires
+// within the refresh skew. It returns an empty token when none has been generated yet.
+func (a *Authenticator) GetTokenWithContext(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	tk := a.token
+	a.mu.Unlock()
+	if tk == nil {
+		return "", nil
+	}
+	if tk.expiresWithin(a.refreshSkew) {
+		var err error
+		if tk, err = a.generate(ctx); err != nil {
+			return "", err
+		}
+	}
+	return tk.AccessToken, nil
+}
+
+// RenewToken replaces an access token the API rejected. When staleToken is no longer the current
+// token, another caller already renewed it and the current token is returned without a new request.
+func (a *Authenticator) RenewToken(ctx context.Context, staleToken string) (string, error) {
+	a.mu.Lock()
+	tk := a.token
+	a.mu.Unlock()
+	if tk == nil || tk.AccessToken == staleToken {
+		var err error
+		if tk, err = a.generate(ctx); err != nil {
+			return "", err
+		}
+	}
+	return tk.AccessToken, nil
+}
+
+// Token returns a copy of the current token and its metadata, generating or refreshing it as needed.
+func (a *Authenticator) Token() (*Token, error) {
+	return a.TokenWithContext(context.Background())
+}
+
+// TokenWithContext returns a copy of the current token and its metadata, generating or refreshing it as needed.
+func (a *Authenticator) TokenWithContext(ctx context.Context) (*Token, error) {
+	a.mu.Lock()
+	tk := a.token
+	a.mu.Unlock()
+	if tk == nil || tk.expiresWithin(a.refreshSkew) {
+		var err error
+		if tk, err = a.generate(ctx); err != nil {
+			return nil, err
+		}
+	}
+	cp := *tk
+	return &cp, nil
 }
@@ -0,0 +1,152 @@
+package authentication
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	olError "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+)
+
+const (
+	// DefaultProfile is the credentials file section used when no profile is selected.
+	DefaultProfile string = "default"
+	// EnvCredentialsFile overrides the location of the credentials file.
+	EnvCredentialsFile string = "ONELOGIN_CREDENTIALS_FILE"
+	// EnvProfile selects the credentials file profile when none is given explicitly.
+	EnvProfile string = "ONELOGIN_PROFILE"
+)
+
+// Profile is a named tenant configuration read from the credentials file, e.g.
+//
+//	[sandbox]
+//	subdomain = acme-sandbox
+//	region = us
+//	client_id = abc
+//	client_secret = xyz
+//	timeout = 15
+type Profile struct {
+	Name         string
+	Subdomain    string
+	Region       string
+	BaseURL      string
+	ClientID     string
+	ClientSecret string
+	Timeout      time.Duration
+}
+
+// ProfileName returns name, or $ONELOGIN_PROFILE, or DefaultProfile, whichever is set first.
+func ProfileName(name string) string {
+	if name != "" {
+		return name
+	}
+	if name := os.Getenv(EnvProfile); name != "" {
+		return name
+	}
+	return DefaultProfile
+}
+
+// LoadProfile reads a profile from the credentials file at path. An empty path selects
+// DefaultCredentialsFile and an empty name is resolved with ProfileName.
+func LoadProfile(path, name string) (*Profile, error) {
+	if path == "" {
+		path = DefaultCredentialsFile()
+	}
+	name = ProfileName(name)
+	section, err := LoadCredentialsFile(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{
+		Name:         name,
+		Subdomain:    section["subdomain"],
+		Region:       section["region"],
+		BaseURL:      section["base_url"],
+		ClientID:     section["client_id"],
+		ClientSecret: section["client_secret"],
+	}
+	if timeout := section["timeout"]; timeout != "" {
+		// Accept plain seconds, like ONELOGIN_TIMEOUT, as well as Go durations such as "1m30s".
+		if seconds, err := strconv.Atoi(timeout); err == nil {
+			profile.Timeout = time.Duration(seconds) * time.Second
+		} else if d, err := time.ParseDuration(timeout); err == nil {
+			profile.Timeout = d
+		} else {
+			return nil, olError.NewSerializationError("Invalid timeout in profile " + name)
+		}
+	}
+	return profile, nil
+}
+
+// DefaultCredentialsFile returns the credentials file location: $ONELOGIN_CREDENTIALS_FILE
+// when set, ~/.onelogin/credentials otherwise.
+func DefaultCredentialsFile() string {
+	if path := os.Getenv(EnvCredentialsFile); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".onelogin", "credentials")
+	}
+	return filepath.Join(home, ".onelogin", "credentials")
+}
+
+// LoadCredentialsFile reads the INI-style credentials file at path and returns the keys of the
+// given profile section, e.g.
+//
+//	[default]
+//	client_id = abc
+//	client_secret = xyz
+func LoadCredentialsFile(path, profile string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, olError.WrapAuthenticationError("Unable to open credentials file "+path, err)
+	}
+	defer f.Close()
+
+	sections, err := parseCredentials(f)
+	if err != nil {
+		return nil, err
+	}
+	section, ok := sections[profile]
+	if !ok {
+		return nil, olError.NewAuthenticationError("Profile " + profile + " not found in " + path)
+	}
+	return section, nil
+}
+
+// parseCredentials parses INI sections of key = value pairs. Lines starting with # or ; are comments.
+func parseCredentials(r io.Reader) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, ";"):
+			continue
+		case strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]"):
+			name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text[1:len(text)-1]), "profile "))
+			if sections[name] == nil {
+				sections[name] = map[string]string{}
+			}
+			current = sections[name]
+		default:
+			key, value, ok := strings.Cut(text, "=")
+			if !ok || current == nil {
+				return nil, olError.NewSerializationError("Malformed credentials file line " + strconv.Itoa(line))
+			}
+			current[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, olError.WrapSerializationError("Unable to read credentials file", err)
+	}
+	return sections, nil
+}
@@ -0,0 +1,61 @@
+package authentication
+
+import (
+	"time"
+)
+
+// DefaultRefreshSkew is how long before expiry an access token is proactively refreshed.
+const DefaultRefreshSkew = time.Minute
+
+// Token holds an access token together with the metadata returned by the token endpoint.
+type Token struct {
+	AccessToken  string    // Bearer token sent with API requests
+	RefreshToken string    // Refresh token issued alongside the access token, if any
+	TokenType    string    // Token type, usually "bearer"
+	AccountID    int       // OneLogin account the token belongs to
+	CreatedAt    time.Time // Creation time reported by OneLogin
+	ExpiresIn    int       // Lifetime in seconds reported by OneLogin
+	Expiry       time.Time // Local time at which the token expires; zero when unknown
+}
+
+// tokenResponse is the JSON body returned by the token endpoint.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	AccountID    int    `json:"account_id"`
+	CreatedAt    string `json:"created_at"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// newToken converts a token endpoint response received at now into a Token.
+// Expiry is computed from the local receipt time to stay independent of clock skew with OneLogin.
+func newToken(r tokenResponse, now time.Time) *Token {
+	tk := &Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		TokenType:    r.TokenType,
+		AccountID:    r.AccountID,
+		ExpiresIn:    r.ExpiresIn,
+	}
+	if createdAt, err := time.Parse(time.RFC3339, r.CreatedAt); err == nil {
+		tk.CreatedAt = createdAt
+	}
+	if r.ExpiresIn > 0 {
+		tk.Expiry = now.Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return tk
+}
+
+// Valid reports whether the token is set and not yet expired.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && !t.expiresWithin(0)
+}
+
+// expiresWithin reports whether the token expires within d. Tokens without a known expiry never do.
+func (t *Token) expiresWithin(d time.Duration) bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return !time.Now().Add(d).Before(t.Expiry)
+}
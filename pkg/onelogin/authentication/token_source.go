@@ -0,0 +1,229 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+
+	olError "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+)
+
+// TokenSource supplies access tokens to the API client.
+// It has the same shape as golang.org/x/oauth2.TokenSource, so an oauth2 source can be
+// adapted by converting its token's AccessToken, TokenType, RefreshToken and Expiry fields.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// ContextTokenSource is implemented by token sources whose token requests honor a context.
+type ContextTokenSource interface {
+	TokenSource
+	TokenWithContext(ctx context.Context) (*Token, error)
+}
+
+// Renewer is implemented by token sources that can replace a token rejected by the API.
+type Renewer interface {
+	RenewToken(ctx context.Context, staleToken string) (string, error)
+}
+
+// Revoker is implemented by token sources that can revoke and forget their current token.
+type Revoker interface {
+	RevokeCurrentToken(ctx context.Context) error
+}
+
+// TokenFromSource returns a token from src, passing ctx along when src supports it.
+func TokenFromSource(ctx context.Context, src TokenSource) (*Token, error) {
+	if cs, ok := src.(ContextTokenSource); ok {
+		return cs.TokenWithContext(ctx)
+	}
+	return src.Token()
+}
+
+type staticTokenSource struct {
+	token Token
+}
+
+// StaticTokenSource returns a TokenSource that always returns the given access token.
+// It suits short-lived tools handed a token by another system; the token is never refreshed.
+func StaticTokenSource(accessToken string) TokenSource {
+	return &staticTokenSource{token: Token{AccessToken: accessToken, TokenType: "bearer"}}
+}
+
+func (s *staticTokenSource) Token() (*Token, error) {
+	tk := s.token
+	return &tk, nil
+}
+
+// lazyTokenSource builds an Authenticator on first use, so that missing credentials
+// surface as an error from Token rather than at construction.
+type lazyTokenSource struct {
+	once    sync.Once
+	load    func() (Config, error)
+	auth    *Authenticator
+	loadErr error
+	missing bool // No credentials were found, as opposed to credentials that failed to load
+}
+
+func (s *lazyTokenSource) authenticator() (*Authenticator, error) {
+	s.once.Do(func() {
+		cfg, err := s.load()
+		if err != nil {
+			s.loadErr = err
+			s.missing = errors.Is(err, os.ErrNotExist)
+			return
+		}
+		if cfg.ClientID == "" || cfg.ClientSecret == "" {
+			s.loadErr = olError.NewAuthenticationError("Missing client ID or client secret")
+			s.missing = true
+			return
+		}
+		s.auth = NewAuthenticatorWithConfig(cfg)
+	})
+	return s.auth, s.loadErr
+}
+
+func (s *lazyTokenSource) Token() (*Token, error) {
+	return s.TokenWithContext(context.Background())
+}
+
+func (s *lazyTokenSource) TokenWithContext(ctx context.Context) (*Token, error) {
+	auth, err := s.authenticator()
+	if err != nil {
+		return nil, err
+	}
+	return auth.TokenWithContext(ctx)
+}
+
+func (s *lazyTokenSource) RenewToken(ctx context.Context, staleToken string) (string, error) {
+	auth, err := s.authenticator()
+	if err != nil {
+		return "", err
+	}
+	return auth.RenewToken(ctx, staleToken)
+}
+
+// unconfigured reports whether the source found no credentials to use.
+func (s *lazyTokenSource) unconfigured() bool {
+	_, err := s.authenticator()
+	return err != nil && s.missing
+}
+
+func (s *lazyTokenSource) RevokeCurrentToken(ctx context.Context) error {
+	auth, err := s.authenticator()
+	if err != nil {
+		// Nothing was ever issued.
+		return nil
+	}
+	return auth.RevokeCurrentToken(ctx)
+}
+
+// EnvTokenSource returns a client credentials TokenSource reading ONELOGIN_CLIENT_ID and
+// ONELOGIN_CLIENT_SECRET on first use. Endpoints and transport are taken from base.
+func EnvTokenSource(base Config) TokenSource {
+	return &lazyTokenSource{load: func() (Config, error) {
+		cfg := base
+		cfg.ClientID = os.Getenv("ONELOGIN_CLIENT_ID")
+		cfg.ClientSecret = os.Getenv("ONELOGIN_CLIENT_SECRET")
+		return cfg, nil
+	}}
+}
+
+// FileTokenSource returns a client credentials TokenSource reading the client ID and secret
+// of the selected profile ($ONELOGIN_PROFILE or "default") in the credentials file at path on
+// first use. An empty path selects DefaultCredentialsFile. Endpoints and transport are taken from base.
+func FileTokenSource(path string, base Config) TokenSource {
+	return ProfileTokenSource(path, "", base)
+}
+
+// ProfileTokenSource is like FileTokenSource for an explicitly named profile.
+func ProfileTokenSource(path, name string, base Config) TokenSource {
+	return &lazyTokenSource{load: func() (Config, error) {
+		profile, err := LoadProfile(path, name)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg := base
+		cfg.ClientID = profile.ClientID
+		cfg.ClientSecret = profile.ClientSecret
+		return cfg, nil
+	}}
+}
+
+// chainTokenSource tries its sources in order and sticks with the first one that yields a token.
+type chainTokenSource struct {
+	mu       sync.Mutex
+	sources  []TokenSource
+	selected TokenSource
+}
+
+// ChainTokenSource returns a TokenSource that tries sources in order. The first source to return
+// a token is used for all later calls. Environment and credentials file sources without credentials
+// are skipped; any other error, such as rejected credentials or a network failure, is returned as
+// is without trying the remaining sources. If every source is skipped, the last error is returned.
+func ChainTokenSource(sources ...TokenSource) TokenSource {
+	return &chainTokenSource{sources: sources}
+}
+
+// DefaultTokenSource returns the standard credentials chain: the explicit credentials in cfg
+// when set, then the environment, then the default credentials file.
+func DefaultTokenSource(cfg Config) TokenSource {
+	var sources []TokenSource
+	if cfg.ClientID != "" && cfg.ClientSecret != "" {
+		sources = append(sources, NewAuthenticatorWithConfig(cfg))
+	}
+	sources = append(sources, EnvTokenSource(cfg), FileTokenSource("", cfg))
+	return ChainTokenSource(sources...)
+}
+
+func (c *chainTokenSource) Token() (*Token, error) {
+	return c.TokenWithContext(context.Background())
+}
+
+func (c *chainTokenSource) TokenWithContext(ctx context.Context) (*Token, error) {
+	c.mu.Lock()
+	selected := c.selected
+	c.mu.Unlock()
+	if selected != nil {
+		return TokenFromSource(ctx, selected)
+	}
+
+	var lastErr error = olError.NewAuthenticationError("No token source configured")
+	for _, src := range c.sources {
+		tk, err := TokenFromSource(ctx, src)
+		if err != nil {
+			if u, ok := src.(interface{ unconfigured() bool }); ok && u.unconfigured() {
+				lastErr = err
+				continue
+			}
+			return nil, err
+		}
+		c.mu.Lock()
+		if c.selected == nil {
+			c.selected = src
+		}
+		c.mu.Unlock()
+		return tk, nil
+	}
+	return nil, lastErr
+}
+
+func (c *chainTokenSource) RenewToken(ctx context.Context, staleToken string) (string, error) {
+	c.mu.Lock()
+	selected := c.selected
+	c.mu.Unlock()
+	if r, ok := selected.(Renewer); ok {
+		return r.RenewToken(ctx, staleToken)
+	}
+	return "", olError.NewAuthenticationError("Token source cannot renew tokens")
+}
+
+func (c *chainTokenSource) RevokeCurrentToken(ctx context.Context) error {
+	c.mu.Lock()
+	selected := c.selected
+	c.mu.Unlock()
+	if r, ok := selected.(Revoker); ok {
+		return r.RevokeCurrentToken(ctx)
+	}
+	return nil
+}
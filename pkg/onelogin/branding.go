@@ -0,0 +1,256 @@
+package onelogin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
+
+const (
+	BrandingPath string = "api/2/branding"
+)
+
+func (sdk *OneloginSDK) ListBrands() ([]mod.Brand, error) {
+	return sdk.ListBrandsWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) ListBrandsWithContext(ctx context.Context) ([]mod.Brand, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands")
+	if err != nil {
+		return nil, err
+	}
+	brands, _, err := api.Do[[]mod.Brand](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return brands, err
+}
+
+func (sdk *OneloginSDK) CreateBrand(brand mod.Brand) (*mod.Brand, error) {
+	return sdk.CreateBrandWithContext(context.Background(), brand)
+}
+
+func (sdk *OneloginSDK) CreateBrandWithContext(ctx context.Context, brand mod.Brand) (*mod.Brand, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands")
+	if err != nil {
+		return nil, err
+	}
+	created, _, err := api.Do[*mod.Brand](ctx, sdk.Client, http.MethodPost, p, nil, brand)
+	return created, err
+}
+
+func (sdk *OneloginSDK) GetBrand(brandID int) (*mod.Brand, error) {
+	return sdk.GetBrandWithContext(context.Background(), brandID)
+}
+
+func (sdk *OneloginSDK) GetBrandWithContext(ctx context.Context, brandID int) (*mod.Brand, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID)
+	if err != nil {
+		return nil, err
+	}
+	brand, _, err := api.Do[*mod.Brand](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return brand, err
+}
+
+func (sdk *OneloginSDK) UpdateBrand(brandID int, brand mod.Brand) (*mod.Brand, error) {
+	return sdk.UpdateBrandWithContext(context.Background(), brandID, brand)
+}
+
+func (sdk *OneloginSDK) UpdateBrandWithContext(ctx context.Context, brandID int, brand mod.Brand) (*mod.Brand, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID)
+	if err != nil {
+		return nil, err
+	}
+	updated, _, err := api.Do[*mod.Brand](ctx, sdk.Client, http.MethodPut, p, nil, brand)
+	return updated, err
+}
+
+func (sdk *OneloginSDK) DeleteBrand(brandID int) error {
+	return sdk.DeleteBrandWithContext(context.Background(), brandID)
+}
+
+func (sdk *OneloginSDK) DeleteBrandWithContext(ctx context.Context, brandID int) error {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID)
+	if err != nil {
+		return err
+	}
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+// ListBrandApps returns the apps using the brand.
+func (sdk *OneloginSDK) ListBrandApps(brandID int) ([]mod.BrandApp, error) {
+	return sdk.ListBrandAppsWithContext(context.Background(), brandID)
+}
+
+func (sdk *OneloginSDK) ListBrandAppsWithContext(ctx context.Context, brandID int) ([]mod.BrandApp, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID, "apps")
+	if err != nil {
+		return nil, err
+	}
+	apps, _, err := api.Do[[]mod.BrandApp](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return apps, err
+}
+
+// AssignAppsToBrand makes the given apps use the brand.
+func (sdk *OneloginSDK) AssignAppsToBrand(brandID int, appIDs []int) error {
+	return sdk.AssignAppsToBrandWithContext(context.Background(), brandID, appIDs)
+}
+
+func (sdk *OneloginSDK) AssignAppsToBrandWithContext(ctx context.Context, brandID int, appIDs []int) error {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID, "apps")
+	if err != nil {
+		return err
+	}
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, appIDs)
+	return err
+}
+
+func (sdk *OneloginSDK) ListMessageTemplates(brandID int) ([]mod.MessageTemplate, error) {
+	return sdk.ListMessageTemplatesWithContext(context.Background(), brandID)
+}
+
+func (sdk *OneloginSDK) ListMessageTemplatesWithContext(ctx context.Context, brandID int) ([]mod.MessageTemplate, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID, "templates")
+	if err != nil {
+		return nil, err
+	}
+	templates, _, err := api.Do[[]mod.MessageTemplate](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return templates, err
+}
+
+func (sdk *OneloginSDK) CreateMessageTemplate(brandID int, template mod.MessageTemplate) (*mod.MessageTemplate, error) {
+	return sdk.CreateMessageTemplateWithContext(context.Background(), brandID, template)
+}
+
+func (sdk *OneloginSDK) CreateMessageTemplateWithContext(ctx context.Context, brandID int, template mod.MessageTemplate) (*mod.MessageTemplate, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID, "templates")
+	if err != nil {
+		return nil, err
+	}
+	created, _, err := api.Do[*mod.MessageTemplate](ctx, sdk.Client, http.MethodPost, p, nil, template)
+	return created, err
+}
+
+func (sdk *OneloginSDK) GetMessageTemplate(brandID, templateID int) (*mod.MessageTemplate, error) {
+	return sdk.GetMessageTemplateWithContext(context.Background(), brandID, templateID)
+}
+
+func (sdk *OneloginSDK) GetMessageTemplateWithContext(ctx context.Context, brandID, templateID int) (*mod.MessageTemplate, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID, "templates", templateID)
+	if err != nil {
+		return nil, err
+	}
+	template, _, err := api.Do[*mod.MessageTemplate](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return template, err
+}
+
+// GetMessageTemplateByType returns the template of the brand with the given type, such as
+// models.TemplateEmailOTP, and locale, such as "en".
+func (sdk *OneloginSDK) GetMessageTemplateByType(brandID int, templateType, locale string) (*mod.MessageTemplate, error) {
+	return sdk.GetMessageTemplateByTypeWithContext(context.Background(), brandID, templateType, locale)
+}
+
+func (sdk *OneloginSDK) GetMessageTemplateByTypeWithContext(ctx context.Context, brandID int, templateType, locale string) (*mod.MessageTemplate, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID, "templates", templateType, locale)
+	if err != nil {
+		return nil, err
+	}
+	template, _, err := api.Do[*mod.MessageTemplate](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return template, err
+}
+
+func (sdk *OneloginSDK) UpdateMessageTemplate(brandID, templateID int, template mod.MessageTemplate) (*mod.MessageTemplate, error) {
+	return sdk.UpdateMessageTemplateWithContext(context.Background(), brandID, templateID, template)
+}
+
+func (sdk *OneloginSDK) UpdateMessageTemplateWithContext(ctx context.Context, brandID, templateID int, template mod.MessageTemplate) (*mod.MessageTemplate, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID, "templates", templateID)
+	if err != nil {
+		return nil, err
+	}
+	updated, _, err := api.Do[*mod.MessageTemplate](ctx, sdk.Client, http.MethodPut, p, nil, template)
+	return updated, err
+}
+
+func (sdk *OneloginSDK) DeleteMessageTemplate(brandID, templateID int) error {
+	return sdk.DeleteMessageTemplateWithContext(context.Background(), brandID, templateID)
+}
+
+func (sdk *OneloginSDK) DeleteMessageTemplateWithContext(ctx context.Context, brandID, templateID int) error {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", brandID, "templates", templateID)
+	if err != nil {
+		return err
+	}
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+// GetMasterTemplate returns the default OneLogin template of the given type, a starting point for
+// custom templates.
+func (sdk *OneloginSDK) GetMasterTemplate(templateType string) (*mod.MessageTemplate, error) {
+	return sdk.GetMasterTemplateWithContext(context.Background(), templateType)
+}
+
+func (sdk *OneloginSDK) GetMasterTemplateWithContext(ctx context.Context, templateType string) (*mod.MessageTemplate, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "brands", "master", "templates", templateType)
+	if err != nil {
+		return nil, err
+	}
+	template, _, err := api.Do[*mod.MessageTemplate](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return template, err
+}
+
+func (sdk *OneloginSDK) GetEmailSettings() (*mod.EmailSettings, error) {
+	return sdk.GetEmailSettingsWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) GetEmailSettingsWithContext(ctx context.Context) (*mod.EmailSettings, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "email_settings")
+	if err != nil {
+		return nil, err
+	}
+	settings, _, err := api.Do[*mod.EmailSettings](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return settings, err
+}
+
+func (sdk *OneloginSDK) UpdateEmailSettings(settings mod.EmailSettings) (*mod.EmailSettings, error) {
+	return sdk.UpdateEmailSettingsWithContext(context.Background(), settings)
+}
+
+func (sdk *OneloginSDK) UpdateEmailSettingsWithContext(ctx context.Context, settings mod.EmailSettings) (*mod.EmailSettings, error) {
+	p, err := utl.BuildAPIPath(BrandingPath, "email_settings")
+	if err != nil {
+		return nil, err
+	}
+	updated, _, err := api.Do[*mod.EmailSettings](ctx, sdk.Client, http.MethodPut, p, nil, settings)
+	return updated, err
+}
+
+// DeleteEmailSettings removes the custom SMTP server, so emails are sent by OneLogin again.
+func (sdk *OneloginSDK) DeleteEmailSettings() error {
+	return sdk.DeleteEmailSettingsWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) DeleteEmailSettingsWithContext(ctx context.Context) error {
+	p, err := utl.BuildAPIPath(BrandingPath, "email_settings")
+	if err != nil {
+		return err
+	}
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+// SendTestEmail sends an email to the given address through the configured SMTP server.
+func (sdk *OneloginSDK) SendTestEmail(email string) error {
+	return sdk.SendTestEmailWithContext(context.Background(), email)
+}
+
+func (sdk *OneloginSDK) SendTestEmailWithContext(ctx context.Context, email string) error {
+	p, err := utl.BuildAPIPath(BrandingPath, "email_settings", "test")
+	if err != nil {
+		return err
+	}
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPost, p, nil, mod.TestEmailRequest{Email: email})
+	return err
+}
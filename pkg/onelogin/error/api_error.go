@@ -1,14 +1,75 @@
 package error
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
 
+// requestIDHeader is the response header carrying the identifier OneLogin assigns to each request.
+const requestIDHeader = "X-Request-Id"
+
+// APIError is returned for responses with a non-2xx status. Its fields are populated from the
+// OneLogin error body when one is present, e.g.
+//
+//	{"statusCode": 422, "name": "UnprocessableEntityError", "message": "Validation failed", "errors": [...]}
+//	{"status": {"error": true, "code": 401, "type": "Unauthorized", "message": "Authentication Failure"}}
 type APIError struct {
-	Message string
-	Code    int
+	Message    string       // OneLogin's description of the error, or the HTTP status text
+	Code       int          // OneLogin error code; the HTTP status when the body carries none
+	StatusCode int          // HTTP status of the response
+	Name       string       // OneLogin error name or type, e.g. "NotFound"
+	Errors     []FieldError // Field-level validation errors
+	Method     string       // Method of the failed request
+	Path       string       // Path of the failed request
+	RequestID  string       // Value of the X-Request-Id response header
+	Body       string       // Raw response body
+	Err        error        // Underlying cause, if any
+}
+
+// FieldError describes a validation error of a single request field.
+type FieldError struct {
+	Field    string
+	Messages []string
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("API error: %s", e.Message)
+	var b strings.Builder
+	b.WriteString("API error: ")
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, "%d ", e.StatusCode)
+	}
+	if e.Name != "" {
+		b.WriteString(e.Name + ": ")
+	}
+	b.WriteString(e.Message)
+	for _, fe := range e.Errors {
+		fmt.Fprintf(&b, "; %s: %s", fe.Field, strings.Join(fe.Messages, ", "))
+	}
+	if e.Method != "" || e.Path != "" {
+		fmt.Fprintf(&b, " (%s %s)", e.Method, e.Path)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " [request id %s]", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is reports whether target is the sentinel error matching the status of e, e.g. ErrNotFound for a 404.
+func (e *APIError) Is(target error) bool {
+	status := e.StatusCode
+	if status == 0 {
+		status = e.Code
+	}
+	return target != nil && target == sentinelForStatus(status)
+}
+
+// Unwrap returns the underlying cause.
+func (e *APIError) Unwrap() error {
+	return e.Err
 }
 
 func NewAPIError(message string, code int) *APIError {
@@ -17,3 +78,141 @@ func NewAPIError(message string, code int) *APIError {
 		Code:    code,
 	}
 }
+
+// NewAPIErrorFromResponse builds an APIError from a failed response, reading and closing its body.
+func NewAPIErrorFromResponse(resp *http.Response) *APIError {
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	e := &APIError{
+		Code:       resp.StatusCode,
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Body:       string(body),
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			e.Path = resp.Request.URL.Path
+		}
+	}
+	e.parseBody(body)
+	if e.Message == "" {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
+
+// apiErrorBody covers the error bodies of the v1 and v2 APIs.
+type apiErrorBody struct {
+	StatusCode       int             `json:"statusCode"`
+	Code             json.RawMessage `json:"code"`
+	Name             string          `json:"name"`
+	Message          json.RawMessage `json:"message"`
+	Description      string          `json:"description"`
+	Error            json.RawMessage `json:"error"`
+	ErrorDescription string          `json:"error_description"`
+	Errors           json.RawMessage `json:"errors"`
+	Status           json.RawMessage `json:"status"`
+}
+
+// apiErrorStatus is the status object of API v1 bodies.
+type apiErrorStatus struct {
+	Code    int    `json:"code"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) parseBody(body []byte) {
+	var b apiErrorBody
+	if err := json.Unmarshal(body, &b); err != nil {
+		return
+	}
+
+	var status apiErrorStatus
+	if json.Unmarshal(b.Status, &status) == nil {
+		if status.Code != 0 {
+			e.Code = status.Code
+		}
+		e.Name = status.Type
+		e.Message = status.Message
+	}
+	if b.StatusCode != 0 {
+		e.Code = b.StatusCode
+	}
+	var code int
+	var codeName string
+	if json.Unmarshal(b.Code, &code) == nil && code != 0 {
+		e.Code = code
+	} else if json.Unmarshal(b.Code, &codeName) == nil && codeName != "" && e.Name == "" {
+		e.Name = codeName
+	}
+	if b.Name != "" {
+		e.Name = b.Name
+	} else if name := messageString(b.Error); name != "" && e.Name == "" {
+		e.Name = name
+	}
+
+	for _, m := range []string{messageString(b.Message), b.Description, b.ErrorDescription} {
+		if m != "" {
+			e.Message = m
+			break
+		}
+	}
+	e.Errors = parseFieldErrors(b.Errors)
+}
+
+// messageString accepts a message given as a string or a list of strings.
+func messageString(raw json.RawMessage) string {
+	return strings.Join(stringList(raw), ", ")
+}
+
+func stringList(raw json.RawMessage) []string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		if s == "" {
+			return nil
+		}
+		return []string{s}
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return list
+	}
+	return nil
+}
+
+// parseFieldErrors accepts [{"field": ..., "message": ...}], {"field": [messages]} and [messages].
+func parseFieldErrors(raw json.RawMessage) []FieldError {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		var errs []FieldError
+		for _, item := range list {
+			var fe struct {
+				Field   string          `json:"field"`
+				Message json.RawMessage `json:"message"`
+			}
+			if json.Unmarshal(item, &fe) == nil {
+				errs = append(errs, FieldError{Field: fe.Field, Messages: stringList(fe.Message)})
+			} else if msgs := stringList(item); msgs != nil {
+				errs = append(errs, FieldError{Messages: msgs})
+			}
+		}
+		return errs
+	}
+
+	var byField map[string]json.RawMessage
+	if json.Unmarshal(raw, &byField) == nil {
+		errs := make([]FieldError, 0, len(byField))
+		for field, msgs := range byField {
+			errs = append(errs, FieldError{Field: field, Messages: stringList(msgs)})
+		}
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+		return errs
+	}
+	return nil
+}
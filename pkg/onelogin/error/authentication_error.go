@@ -1,19 +1,52 @@
 package error
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 )
 
 type AuthenticationError struct {
 	Message string
+	Err     error // Underlying cause, if any
 }
 
 func (e *AuthenticationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("Authentication error: %s: %v", e.Message, e.Err)
+	}
 	return fmt.Sprintf("Authentication error: %s", e.Message)
 }
 
+// Is reports whether target is ErrUnauthorized and the error is a rejection of the credentials:
+// it has no cause, or its cause is a 401 or 403 APIError. Other causes, such as network failures
+// or a rate limited token endpoint, are matched by errors.Is through Unwrap instead.
+func (e *AuthenticationError) Is(target error) bool {
+	if target != ErrUnauthorized {
+		return false
+	}
+	if e.Err == nil {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(e.Err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// Unwrap returns the underlying cause.
+func (e *AuthenticationError) Unwrap() error {
+	return e.Err
+}
+
 func NewAuthenticationError(message string) *AuthenticationError {
 	return &AuthenticationError{
 		Message: message,
 	}
 }
+
+// WrapAuthenticationError returns a AuthenticationError with the given message wrapping err.
+func WrapAuthenticationError(message string, err error) *AuthenticationError {
+	return &AuthenticationError{
+		Message: message,
+		Err:     err,
+	}
+}
@@ -6,14 +6,31 @@ import (
 
 type RequestError struct {
 	Message string
+	Err     error // Underlying cause, if any
 }
 
 func (e RequestError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("Request error: %s: %v", e.Message, e.Err)
+	}
 	return fmt.Sprintf("Request error: %s", e.Message)
 }
 
+// Unwrap returns the underlying cause.
+func (e RequestError) Unwrap() error {
+	return e.Err
+}
+
 func NewRequestError(message string) *RequestError {
 	return &RequestError{
 		Message: message,
 	}
 }
+
+// WrapRequestError returns a RequestError with the given message wrapping err.
+func WrapRequestError(message string, err error) *RequestError {
+	return &RequestError{
+		Message: message,
+		Err:     err,
+	}
+}
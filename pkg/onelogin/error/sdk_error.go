@@ -4,14 +4,31 @@ import "fmt"
 
 type SDKError struct {
 	Message string
+	Err     error // Underlying cause, if any
 }
 
 func (e SDKError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("SDK error: %s: %v", e.Message, e.Err)
+	}
 	return fmt.Sprintf("SDK error: %s", e.Message)
 }
 
+// Unwrap returns the underlying cause.
+func (e SDKError) Unwrap() error {
+	return e.Err
+}
+
 func NewSDKError(message string) error {
 	return SDKError{
 		Message: message,
 	}
 }
+
+// WrapSDKError returns a SDKError with the given message wrapping err.
+func WrapSDKError(message string, err error) error {
+	return SDKError{
+		Message: message,
+		Err:     err,
+	}
+}
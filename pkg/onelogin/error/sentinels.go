@@ -0,0 +1,58 @@
+package error
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors for branching on the kind of failure with errors.Is. An *APIError matches the
+// sentinel of its HTTP status, and an *AuthenticationError matches ErrUnauthorized.
+var (
+	ErrNotFound     = errors.New("not found")    // HTTP 404
+	ErrUnauthorized = errors.New("unauthorized") // HTTP 401 or a failed authentication
+	ErrForbidden    = errors.New("forbidden")    // HTTP 403
+	ErrConflict     = errors.New("conflict")     // HTTP 409
+	ErrRateLimited  = errors.New("rate limited") // HTTP 429
+)
+
+// sentinelForStatus returns the sentinel error matching an HTTP status, or nil.
+func sentinelForStatus(status int) error {
+	switch status {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// IsNotFound reports whether err indicates that the requested resource does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether err indicates missing or rejected credentials.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err indicates that the credentials lack the required permission.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsConflict reports whether err indicates a conflict with the current state of a resource.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsRateLimited reports whether err indicates that the rate limit was exceeded.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
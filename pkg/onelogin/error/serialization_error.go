@@ -6,14 +6,31 @@ import (
 
 type SerializationError struct {
 	Message string
+	Err     error // Underlying cause, if any
 }
 
 func (e SerializationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("Serialization error: %s: %v", e.Message, e.Err)
+	}
 	return fmt.Sprintf("Serialization error: %s", e.Message)
 }
 
+// Unwrap returns the underlying cause.
+func (e SerializationError) Unwrap() error {
+	return e.Err
+}
+
 func NewSerializationError(message string) error {
 	return SerializationError{
 		Message: message,
 	}
 }
+
+// WrapSerializationError returns a SerializationError with the given message wrapping err.
+func WrapSerializationError(message string, err error) error {
+	return SerializationError{
+		Message: message,
+		Err:     err,
+	}
+}
@@ -0,0 +1,229 @@
+package onelogin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+// DefaultTailInterval is the time between two polls of the events endpoint by Tail.
+const DefaultTailInterval time.Duration = 30 * time.Second
+
+// Checkpoint is the position of an event stream: the creation time of the newest delivered
+// event and the ids of the delivered events created at that time, which the next poll returns again.
+type Checkpoint struct {
+	Since   time.Time `json:"since"`
+	SeenIDs []int64   `json:"seen_ids,omitempty"`
+}
+
+// CheckpointStore persists the checkpoint of an event stream so a restarted stream resumes
+// where the previous one stopped.
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, or a zero Checkpoint when none was saved.
+	Load(ctx context.Context) (Checkpoint, error)
+	Save(ctx context.Context, cp Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory. The zero value is ready to use.
+type MemoryCheckpointStore struct {
+	mu sync.Mutex
+	cp Checkpoint
+}
+
+func (s *MemoryCheckpointStore) Load(context.Context) (Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cp, nil
+}
+
+func (s *MemoryCheckpointStore) Save(_ context.Context, cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cp = cp
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in a JSON file, replaced atomically on every save.
+type FileCheckpointStore struct {
+	Path string
+}
+
+func (s FileCheckpointStore) Load(context.Context) (Checkpoint, error) {
+	var cp Checkpoint
+	b, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return cp, err
+	}
+	err = json.Unmarshal(b, &cp)
+	return cp, err
+}
+
+func (s FileCheckpointStore) Save(_ context.Context, cp Checkpoint) error {
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// TailOptions configures Tail.
+type TailOptions struct {
+	Query    *mod.EventQuery // Filters such as the event type; Since, Cursor and Sort are set by the poller
+	Interval time.Duration   // Time between polls; DefaultTailInterval when zero
+	Store    CheckpointStore // Checkpoint persistence; in memory when nil
+	Start    time.Time       // Position of a stream without a saved checkpoint; the current time when zero
+	Buffer   int             // Capacity of the event channel; unbuffered when zero
+	OnError  func(error)     // Called with the errors of failed polls and checkpoint saves, which are retried
+}
+
+// Tail polls the events endpoint every opts.Interval and delivers new events on the returned
+// channel in creation order, until ctx is done and the channel is closed. Each poll asks for the
+// events created since the newest delivered one and drops those already delivered. The poller
+// blocks while the channel is full, so a slow consumer delays the next poll rather than losing
+// events. Polls walk the result one page at a time and save the checkpoint after every page;
+// after a crash, the events delivered since the last save are delivered again.
+func (s *EventsService) Tail(ctx context.Context, opts TailOptions) (<-chan mod.Event, error) {
+	if opts.Store == nil {
+		opts.Store = &MemoryCheckpointStore{}
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultTailInterval
+	}
+	cp, err := opts.Store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cp.Since.IsZero() {
+		cp.Since = opts.Start
+		if cp.Since.IsZero() {
+			cp.Since = time.Now()
+		}
+	}
+
+	events := make(chan mod.Event, opts.Buffer)
+	t := &tail{events: s, opts: opts, cp: cp, out: events}
+	go t.run(ctx)
+	return events, nil
+}
+
+// tail is the state of a running Tail poller.
+type tail struct {
+	events *EventsService
+	opts   TailOptions
+	cp     Checkpoint
+	out    chan<- mod.Event
+}
+
+func (t *tail) run(ctx context.Context) {
+	defer close(t.out)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		if !t.poll(ctx) {
+			return
+		}
+		timer.Reset(t.opts.Interval)
+	}
+}
+
+// poll delivers the events created since the checkpoint one page at a time, saving the checkpoint
+// after every page. It returns false when ctx is done.
+func (t *tail) poll(ctx context.Context) bool {
+	var q mod.EventQuery
+	if t.opts.Query != nil {
+		q = *t.opts.Query
+	}
+	since := t.cp.Since
+	q.Since, q.Cursor, q.Sort = &since, "", "id"
+
+	seen := make(map[int64]bool, len(t.cp.SeenIDs))
+	for _, id := range t.cp.SeenIDs {
+		seen[id] = true
+	}
+	pages := t.events.List(ctx, &q)
+	for pages.NextPage() {
+		if !t.deliver(ctx, pages.Page(), since, seen) {
+			return false
+		}
+	}
+	if err := pages.Err(); err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		t.report(err)
+	}
+	return true
+}
+
+// deliver sends the events of a page in creation order, skipping those created before the
+// position the poll started from or already delivered, and saves the advanced checkpoint.
+// It returns false when ctx is done.
+func (t *tail) deliver(ctx context.Context, page []mod.Event, since time.Time, seen map[int64]bool) bool {
+	sort.SliceStable(page, func(i, j int) bool {
+		if !page[i].CreatedAt.Equal(page[j].CreatedAt) {
+			return page[i].CreatedAt.Before(page[j].CreatedAt)
+		}
+		return page[i].ID < page[j].ID
+	})
+
+	delivered := true
+	for _, event := range page {
+		if seen[event.ID] || event.CreatedAt.Before(since) {
+			continue
+		}
+		select {
+		case t.out <- event:
+		case <-ctx.Done():
+			delivered = false
+		}
+		if !delivered {
+			break
+		}
+		seen[event.ID] = true
+		if event.CreatedAt.After(t.cp.Since) {
+			t.cp.Since, t.cp.SeenIDs = event.CreatedAt, nil
+		}
+		if event.CreatedAt.Equal(t.cp.Since) {
+			t.cp.SeenIDs = append(t.cp.SeenIDs, event.ID)
+		}
+	}
+
+	// Save with a fresh context so the progress made before cancellation is kept.
+	if err := t.opts.Store.Save(context.Background(), t.cp); err != nil {
+		t.report(err)
+	}
+	return delivered
+}
+
+func (t *tail) report(err error) {
+	if t.opts.OnError != nil {
+		t.opts.OnError(err)
+	}
+}
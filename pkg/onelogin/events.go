@@ -0,0 +1,78 @@
+package onelogin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
+
+const (
+	EventsPath string = "api/1/events"
+)
+
+// ListEvents returns the first page of events matching query. Use sdk.Events.List to walk every page.
+func (sdk *OneloginSDK) ListEvents(query *mod.EventQuery) ([]mod.Event, error) {
+	return sdk.ListEventsWithContext(context.Background(), query)
+}
+
+func (sdk *OneloginSDK) ListEventsWithContext(ctx context.Context, query *mod.EventQuery) ([]mod.Event, error) {
+	events, _, err := listEvents(ctx, sdk.Client, query)
+	return events, err
+}
+
+// GetEvent returns the event with the given id.
+func (sdk *OneloginSDK) GetEvent(eventID int64) (*mod.Event, error) {
+	return sdk.GetEventWithContext(context.Background(), eventID)
+}
+
+func (sdk *OneloginSDK) GetEventWithContext(ctx context.Context, eventID int64) (*mod.Event, error) {
+	p, err := utl.BuildAPIPath(EventsPath, eventID)
+	if err != nil {
+		return nil, err
+	}
+	// API v1 returns the event as a single element list.
+	events, _, err := api.Do[[]mod.Event](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, olerror.NewSDKError(fmt.Sprintf("no event %d in the response", eventID))
+	}
+	return &events[0], nil
+}
+
+// ListEventTypes returns every event type with its id, name and description.
+func (sdk *OneloginSDK) ListEventTypes() ([]mod.EventType, error) {
+	return sdk.ListEventTypesWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) ListEventTypesWithContext(ctx context.Context) ([]mod.EventType, error) {
+	p, err := utl.BuildAPIPath(EventsPath, "types")
+	if err != nil {
+		return nil, err
+	}
+	types, _, err := api.Do[[]mod.EventType](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return types, err
+}
+
+// listEvents fetches one page of events matching query, which may be nil.
+func listEvents(ctx context.Context, client *api.Client, query *mod.EventQuery) ([]mod.Event, *api.Response, error) {
+	p, err := utl.BuildAPIPath(EventsPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	var q mod.Queryable
+	if query != nil {
+		if !utl.ValidateQueryParams(query, query.GetKeyValidators()) {
+			return nil, nil, errors.New("invalid query parameters")
+		}
+		q = query
+	}
+	return api.Do[[]mod.Event](ctx, client, http.MethodGet, p, q, nil)
+}
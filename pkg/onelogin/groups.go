@@ -1,28 +1,40 @@
 package onelogin
 
-import utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
 
 const (
 	GroupsPath = "api/1/groups"
 )
 
-func (sdk *OneloginSDK) GetGroupByID(groupID int) (interface{}, error) {
+func (sdk *OneloginSDK) GetGroupByID(groupID int) (*mod.Group, error) {
+	return sdk.GetGroupByIDWithContext(context.Background(), groupID)
+}
+
+func (sdk *OneloginSDK) GetGroupByIDWithContext(ctx context.Context, groupID int) (*mod.Group, error) {
 	p, err := utl.BuildAPIPath(GroupsPath, groupID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	group, _, err := api.Do[*mod.Group](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return group, err
+}
+
+func (sdk *OneloginSDK) GetGroups() ([]mod.Group, error) {
+	return sdk.GetGroupsWithContext(context.Background())
 }
 
-func (sdk *OneloginSDK) GetGroups() (interface{}, error) {
-	p := GroupsPath
-	resp, err := sdk.Client.Get(&p, nil)
+func (sdk *OneloginSDK) GetGroupsWithContext(ctx context.Context) ([]mod.Group, error) {
+	p, err := utl.BuildAPIPath(GroupsPath)
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	groups, _, err := api.Do[[]mod.Group](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return groups, err
 }
@@ -1,6 +1,10 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -10,92 +14,99 @@ const (
 )
 
 // https://<subdomain>/api/2/mfa/users/<user_id>/factors
-func (sdk *OneloginSDK) GetAvailableMFAFactors(userID int) (interface{}, error) {
+func (sdk *OneloginSDK) GetAvailableMFAFactors(userID int) ([]models.MFAFactor, error) {
+	return sdk.GetAvailableMFAFactorsWithContext(context.Background(), userID)
+}
+
+func (sdk *OneloginSDK) GetAvailableMFAFactorsWithContext(ctx context.Context, userID int) ([]models.MFAFactor, error) {
 	p, err := utl.BuildAPIPath(MFAPath, userID, "factors")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	factors, _, err := api.Do[[]models.MFAFactor](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return factors, err
 }
 
 // https://<subdomain>/api/2/mfa/users/<user_id>/registrations
-func (sdk *OneloginSDK) EnrollMFAFactor(factor models.EnrollFactorRequest, userID int) (interface{}, error) {
+func (sdk *OneloginSDK) EnrollMFAFactor(factor models.EnrollFactorRequest, userID int) (*models.MFARegistration, error) {
+	return sdk.EnrollMFAFactorWithContext(context.Background(), factor, userID)
+}
+
+func (sdk *OneloginSDK) EnrollMFAFactorWithContext(ctx context.Context, factor models.EnrollFactorRequest, userID int) (*models.MFARegistration, error) {
 	p, err := utl.BuildAPIPath(MFAPath, userID, "registrations")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, factor)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	registration, _, err := api.Do[*models.MFARegistration](ctx, sdk.Client, http.MethodPost, p, nil, factor)
+	return registration, err
 }
 
 // https://<subdomain>/api/2/mfa/users/<user_id>/registrations/<registration_id>
-func (sdk *OneloginSDK) VerifyMFAEnrollment(userID, registrationID, otp int) (interface{}, error) {
+func (sdk *OneloginSDK) VerifyMFAEnrollment(userID, registrationID, otp int) (*models.MFARegistration, error) {
+	return sdk.VerifyMFAEnrollmentWithContext(context.Background(), userID, registrationID, otp)
+}
+
+func (sdk *OneloginSDK) VerifyMFAEnrollmentWithContext(ctx context.Context, userID, registrationID, otp int) (*models.MFARegistration, error) {
 	p, err := utl.BuildAPIPath(MFAPath, userID, "registrations", registrationID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, otp)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	registration, _, err := api.Do[*models.MFARegistration](ctx, sdk.Client, http.MethodPut, p, nil, otp)
+	return registration, err
 }
 
 // https://<subdomain>/api/2/mfa/users/<user_id>/verifications
-func (sdk *OneloginSDK) ActivateMFAFactor(userID int, request models.ActivateFactorRequest) (interface{}, error) {
+func (sdk *OneloginSDK) ActivateMFAFactor(userID int, request models.ActivateFactorRequest) (*models.MFAVerification, error) {
+	return sdk.ActivateMFAFactorWithContext(context.Background(), userID, request)
+}
+
+func (sdk *OneloginSDK) ActivateMFAFactorWithContext(ctx context.Context, userID int, request models.ActivateFactorRequest) (*models.MFAVerification, error) {
 	p, err := utl.BuildAPIPath(MFAPath, userID, "verifications")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, request)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	verification, _, err := api.Do[*models.MFAVerification](ctx, sdk.Client, http.MethodPost, p, nil, request)
+	return verification, err
 }
 
 // https://<subdomain>/api/2/mfa/users/<user_id>/devices/<device_id>
-func (sdk *OneloginSDK) RemoveMFAFactor(userID, deviceID int) (interface{}, error) {
+func (sdk *OneloginSDK) RemoveMFAFactor(userID, deviceID int) error {
+	return sdk.RemoveMFAFactorWithContext(context.Background(), userID, deviceID)
+}
+
+func (sdk *OneloginSDK) RemoveMFAFactorWithContext(ctx context.Context, userID, deviceID int) error {
 	p, err := utl.BuildAPIPath(MFAPath, userID, "devices", deviceID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
 
-// https://<subdomain>/api/2/mfa/users/<user_id>/factors
-func (sdk *OneloginSDK) GetEnrolledMFAFactors(userID int) (interface{}, error) {
-	p, err := utl.BuildAPIPath(MFAPath, userID, "factors")
-	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Get(&p, nil)
+// https://<subdomain>/api/2/mfa/users/<user_id>/devices
+func (sdk *OneloginSDK) GetEnrolledMFAFactors(userID int) ([]models.MFADevice, error) {
+	return sdk.GetEnrolledMFAFactorsWithContext(context.Background(), userID)
+}
+
+func (sdk *OneloginSDK) GetEnrolledMFAFactorsWithContext(ctx context.Context, userID int) ([]models.MFADevice, error) {
+	p, err := utl.BuildAPIPath(MFAPath, userID, "devices")
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	devices, _, err := api.Do[[]models.MFADevice](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return devices, err
 }
 
 // https://<subdomain>/api/2/mfa/users/:user_id/mfa_token
-func (sdk *OneloginSDK) GenerateMFAToken(userID int, request models.GenerateMFATokenRequest) (interface{}, error) {
+func (sdk *OneloginSDK) GenerateMFAToken(userID int, request models.GenerateMFATokenRequest) (*models.MFAToken, error) {
+	return sdk.GenerateMFATokenWithContext(context.Background(), userID, request)
+}
+
+func (sdk *OneloginSDK) GenerateMFATokenWithContext(ctx context.Context, userID int, request models.GenerateMFATokenRequest) (*models.MFAToken, error) {
 	p, err := utl.BuildAPIPath(MFAPath, userID, "mfa_token")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, request)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	token, _, err := api.Do[*models.MFAToken](ctx, sdk.Client, http.MethodPost, p, nil, request)
+	return token, err
 }
@@ -137,3 +137,12 @@ func (q *AppQuery) GetKeyValidators() map[string]func(interface{}) bool {
 		"auth_method":  validateInt,
 	}
 }
+
+// Connector is an app template available in the OneLogin catalog; apps are created from a connector.
+type Connector struct {
+	ID                  int32  `json:"id"`
+	Name                string `json:"name"`
+	IconURL             string `json:"icon_url,omitempty"`
+	AuthMethod          int    `json:"auth_method"`
+	AllowsNewParameters bool   `json:"allows_new_parameters"`
+}
@@ -15,6 +15,7 @@ type Action struct {
 }
 
 type AppRule struct {
+	ID         int         `json:"id,omitempty"`
 	AppID      int         `json:"app_id"`
 	Name       string      `json:"name"`
 	Enabled    bool        `json:"enabled"`
@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// Message template types
+const (
+	TemplateEmailForgotPassword     string = "email_forgot_password"
+	TemplateEmailForgotPasswordCode string = "email_forgot_password_code"
+	TemplateEmailMagicLink          string = "email_magic_link"
+	TemplateEmailOTP                string = "email_otp"
+	TemplateSMSOTP                  string = "sms_otp"
+)
+
+// Brand represents the custom branding of the login and portal pages
+type Brand struct {
+	ID                              *int        `json:"id,omitempty"`
+	Name                            *string     `json:"name,omitempty"`
+	Enabled                         *bool       `json:"enabled,omitempty"`
+	CustomSupportEnabled            *bool       `json:"custom_support_enabled,omitempty"`
+	CustomColor                     *string     `json:"custom_color,omitempty"`
+	CustomAccentColor               *string     `json:"custom_accent_color,omitempty"`
+	CustomMaskingColor              *string     `json:"custom_masking_color,omitempty"`
+	CustomMaskingOpacity            *int        `json:"custom_masking_opacity,omitempty"`
+	EnableCustomLabelForLoginScreen *bool       `json:"enable_custom_label_for_login_screen,omitempty"`
+	CustomLabelTextForLoginScreen   *string     `json:"custom_label_text_for_login_screen,omitempty"`
+	LoginInstructionTitle           *string     `json:"login_instruction_title,omitempty"`
+	LoginInstruction                *string     `json:"login_instruction,omitempty"`
+	MFAEnrollmentMessage            *string     `json:"mfa_enrollment_message,omitempty"`
+	HideOneLoginFooter              *bool       `json:"hide_onelogin_footer,omitempty"`
+	Background                      *BrandImage `json:"background,omitempty"`
+	Logo                            *BrandImage `json:"logo,omitempty"`
+	CreatedAt                       *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt                       *time.Time  `json:"updated_at,omitempty"`
+}
+
+// BrandImage describes an uploaded background or logo image
+type BrandImage struct {
+	OriginalURL string `json:"original_url,omitempty"`
+	FileSize    int    `json:"file_size,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// BrandApp is an app using a brand
+type BrandApp struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	ConnectorID int    `json:"connector_id,omitempty"`
+}
+
+// MessageTemplate represents a customized email or SMS sent to users of a brand
+type MessageTemplate struct {
+	ID       *int             `json:"id,omitempty"`
+	Type     *string          `json:"type,omitempty"`
+	Locale   *string          `json:"locale,omitempty"` // e.g. "en" or "pt-BR"
+	Template *MessageContents `json:"template,omitempty"`
+}
+
+// MessageContents holds the text of a MessageTemplate; Message is used by SMS templates
+type MessageContents struct {
+	Subject *string `json:"subject,omitempty"`
+	HTML    *string `json:"html,omitempty"`
+	Plain   *string `json:"plain,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+// EmailSettings represents the SMTP server used to send the account emails
+type EmailSettings struct {
+	Address  *string `json:"address,omitempty"`
+	UseTLS   *bool   `json:"use_tls,omitempty"`
+	From     *string `json:"from,omitempty"`
+	Domain   *string `json:"domain,omitempty"`
+	UserName *string `json:"user_name,omitempty"`
+	Password *string `json:"password,omitempty"`
+	Port     *int    `json:"port,omitempty"`
+}
+
+// TestEmailRequest is the body of a test email request
+type TestEmailRequest struct {
+	Email string `json:"email"`
+}
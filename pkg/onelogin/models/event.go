@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// Event type ids of common events. ListEventTypes returns the full list.
+const (
+	EventTypeUserLoggedIntoOneLogin   int = 5
+	EventTypeUserFailedAuthentication int = 6
+	EventTypeUserLoggedIntoApp        int = 8
+	EventTypeUserCreated              int = 13
+	EventTypeUserDeleted              int = 17
+)
+
+// EventQuery represents available query parameters
+type EventQuery struct {
+	Limit        string     `json:"limit,omitempty"`
+	Cursor       string     `json:"after_cursor,omitempty"`
+	BeforeCursor string     `json:"before_cursor,omitempty"`
+	Sort         string     `json:"sort,omitempty"` // "id" or "-id" for descending order
+	EventTypeID  *int       `json:"event_type_id,omitempty"`
+	UserID       *int       `json:"user_id,omitempty"`
+	DirectoryID  *int       `json:"directory_id,omitempty"`
+	ClientID     *string    `json:"client_id,omitempty"`
+	Resolution   *string    `json:"resolution,omitempty"`
+	Since        *time.Time `json:"since,omitempty"`
+	Until        *time.Time `json:"until,omitempty"`
+}
+
+func (q *EventQuery) GetKeyValidators() map[string]func(interface{}) bool {
+	return map[string]func(interface{}) bool{
+		"limit":         validateString,
+		"after_cursor":  validateString,
+		"before_cursor": validateString,
+		"sort":          validateString,
+		"event_type_id": validateInt,
+		"user_id":       validateInt,
+		"directory_id":  validateInt,
+		"client_id":     validateString,
+		"resolution":    validateString,
+		"since":         validateTime,
+		"until":         validateTime,
+	}
+}
+
+// Event represents an entry of the OneLogin event log
+type Event struct {
+	ID                   int64     `json:"id"`
+	CreatedAt            time.Time `json:"created_at"`
+	AccountID            int       `json:"account_id"`
+	EventTypeID          int       `json:"event_type_id"`
+	UserID               int       `json:"user_id,omitempty"`
+	UserName             string    `json:"user_name,omitempty"`
+	ActorUserID          int       `json:"actor_user_id,omitempty"`
+	ActorUserName        string    `json:"actor_user_name,omitempty"`
+	ActorSystem          string    `json:"actor_system,omitempty"`
+	AssumingActingUserID int       `json:"assuming_acting_user_id,omitempty"`
+	AppID                int       `json:"app_id,omitempty"`
+	AppName              string    `json:"app_name,omitempty"`
+	RoleID               int       `json:"role_id,omitempty"`
+	RoleName             string    `json:"role_name,omitempty"`
+	GroupID              int       `json:"group_id,omitempty"`
+	GroupName            string    `json:"group_name,omitempty"`
+	PolicyID             int       `json:"policy_id,omitempty"`
+	PolicyName           string    `json:"policy_name,omitempty"`
+	OTPDeviceID          int       `json:"otp_device_id,omitempty"`
+	OTPDeviceName        string    `json:"otp_device_name,omitempty"`
+	DirectoryID          int       `json:"directory_id,omitempty"`
+	DirectorySyncRunID   int       `json:"directory_sync_run_id,omitempty"`
+	ClientID             string    `json:"client_id,omitempty"`
+	ResourceTypeID       int       `json:"resource_type_id,omitempty"`
+	IPAddr               string    `json:"ipaddr,omitempty"`
+	ProxyIP              string    `json:"proxy_ip,omitempty"`
+	Notes                string    `json:"notes,omitempty"`
+	CustomMessage        string    `json:"custom_message,omitempty"`
+	OperationName        string    `json:"operation_name,omitempty"`
+	Resolution           string    `json:"resolution,omitempty"`
+	ErrorDescription     string    `json:"error_description,omitempty"`
+	RiskScore            int       `json:"risk_score,omitempty"`
+	RiskReasons          string    `json:"risk_reasons,omitempty"`
+	RiskCookieID         string    `json:"risk_cookie_id,omitempty"`
+	BrowserFingerprint   string    `json:"browser_fingerprint,omitempty"`
+}
+
+// EventType describes a kind of event
+type EventType struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
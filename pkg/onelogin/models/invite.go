@@ -0,0 +1,12 @@
+package models
+
+// GenerateInviteLinkRequest is the body of a request for the invite link of a user
+type GenerateInviteLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// SendInviteLinkRequest is the body of a request to email the invite link of a user
+type SendInviteLinkRequest struct {
+	Email         string `json:"email"`
+	PersonalEmail string `json:"personal_email,omitempty"` // Recipient instead of Email when set
+}
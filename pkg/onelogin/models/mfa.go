@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type GenerateSAMLTokenRequest struct {
 	UsernameOrEmail string `json:"username_or_email"`
 	Password        string `json:"password"`
@@ -35,3 +37,72 @@ type GenerateMFATokenRequest struct {
 	ExpiresIn string `json:"expires_in,omitempty"`
 	Reusable  bool   `json:"reusable,omitempty"`
 }
+
+// MFAFactor is an authentication factor available to a user
+type MFAFactor struct {
+	FactorID       int    `json:"factor_id"`
+	Name           string `json:"name"`
+	AuthFactorName string `json:"auth_factor_name"`
+}
+
+// MFADevice is an authentication factor enrolled by a user
+type MFADevice struct {
+	DeviceID        string `json:"device_id"`
+	UserDisplayName string `json:"user_display_name,omitempty"`
+	TypeDisplayName string `json:"type_display_name,omitempty"`
+	AuthFactorName  string `json:"auth_factor_name,omitempty"`
+	Default         bool   `json:"default"`
+}
+
+// MFARegistration is the state of a factor enrollment
+type MFARegistration struct {
+	ID          int        `json:"id"`
+	Status      string     `json:"status"` // e.g. "pending" or "accepted"
+	UserID      int        `json:"user_id,omitempty"`
+	FactorID    int        `json:"factor_id,omitempty"`
+	DeviceID    string     `json:"device_id,omitempty"`
+	DisplayName string     `json:"display_name,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// MFAVerification is the state of an authentication challenge sent to an enrolled factor
+type MFAVerification struct {
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	UserID    int        `json:"user_id,omitempty"`
+	DeviceID  string     `json:"device_id,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// MFAToken is a temporary token a user can authenticate with instead of a factor
+type MFAToken struct {
+	MFAToken  string     `json:"mfa_token"`
+	Reusable  bool       `json:"reusable"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// SAMLAssertion is the result of a SAML assertion request. Data holds the base64 encoded assertion;
+// when MFA is required it is empty and StateToken and Devices are used to verify a factor.
+type SAMLAssertion struct {
+	Data        string       `json:"data,omitempty"`
+	Message     string       `json:"message,omitempty"`
+	StateToken  string       `json:"state_token,omitempty"`
+	Devices     []SAMLDevice `json:"devices,omitempty"`
+	CallbackURL string       `json:"callback_url,omitempty"`
+	User        *SAMLUser    `json:"user,omitempty"`
+}
+
+// SAMLDevice is a factor that can verify a SAML assertion request
+type SAMLDevice struct {
+	DeviceID   int    `json:"device_id"`
+	DeviceType string `json:"device_type"`
+}
+
+// SAMLUser is the user a SAML assertion was requested for
+type SAMLUser struct {
+	ID        int    `json:"id"`
+	Username  string `json:"username,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Firstname string `json:"firstname,omitempty"`
+	Lastname  string `json:"lastname,omitempty"`
+}
@@ -30,6 +30,15 @@ type StatementData struct {
 	Scope  []string `json:"Scope"`
 }
 
+// PrivilegeAssignments lists the users or roles a privilege is assigned to
+type PrivilegeAssignments struct {
+	Total        int     `json:"total"`
+	Users        []int   `json:"users,omitempty"`
+	Roles        []int   `json:"roles,omitempty"`
+	BeforeCursor *string `json:"beforeCursor,omitempty"`
+	AfterCursor  *string `json:"afterCursor,omitempty"`
+}
+
 func (p *Privilege) GetKeyValidators() map[string]func(interface{}) bool {
 	return map[string]func(interface{}) bool{
 		"limit":  validateString,
@@ -37,3 +46,11 @@ func (p *Privilege) GetKeyValidators() map[string]func(interface{}) bool {
 		"cursor": validateString,
 	}
 }
+
+func (q *PrivilegeQuery) GetKeyValidators() map[string]func(interface{}) bool {
+	return map[string]func(interface{}) bool{
+		"limit":  validateString,
+		"page":   validateString,
+		"cursor": validateString,
+	}
+}
@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+// Risk rule types
+const (
+	RiskRuleBlacklist string = "blacklist"
+	RiskRuleWhitelist string = "whitelist"
+)
+
+// Risk event verbs
+const (
+	RiskVerbLogIn  string = "log-in"
+	RiskVerbLogOut string = "log-out"
+)
+
+// RiskRule represents a Vigilance AI rule adjusting the risk score of matching requests
+type RiskRule struct {
+	ID          *string  `json:"id,omitempty"`
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Type        *string  `json:"type,omitempty"`   // RiskRuleBlacklist or RiskRuleWhitelist
+	Target      *string  `json:"target,omitempty"` // Request attribute the filters apply to, e.g. "location.ip"
+	Filters     []string `json:"filters,omitempty"`
+	Source      *string  `json:"source,omitempty"` // ID of a OneLogin provided list used instead of filters
+}
+
+// RiskContext describes the login attempt being scored or tracked
+type RiskContext struct {
+	IP          string       `json:"ip"`
+	UserAgent   string       `json:"user_agent"`
+	User        RiskUser     `json:"user"`
+	Source      *RiskSource  `json:"source,omitempty"`
+	Session     *RiskSession `json:"session,omitempty"`
+	Device      *RiskDevice  `json:"device,omitempty"`
+	Fingerprint string       `json:"fp,omitempty"` // Browser fingerprint
+}
+
+// RiskUser identifies the user of a RiskContext
+type RiskUser struct {
+	ID            string `json:"id"`
+	Name          string `json:"name,omitempty"`
+	Authenticated bool   `json:"authenticated"`
+}
+
+// RiskSource identifies the application reporting a RiskContext
+type RiskSource struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// RiskSession identifies the session of a RiskContext
+type RiskSession struct {
+	ID string `json:"id"`
+}
+
+// RiskDevice identifies the device of a RiskContext
+type RiskDevice struct {
+	ID string `json:"id"`
+}
+
+// RiskEvent is a login or logout reported to Vigilance AI to train its risk model
+type RiskEvent struct {
+	Verb string `json:"verb"` // RiskVerbLogIn or RiskVerbLogOut
+	RiskContext
+	Published *time.Time `json:"published,omitempty"` // Time of the event; now when nil
+}
+
+// RiskScore is the risk assessment of a RiskContext
+type RiskScore struct {
+	Score    int      `json:"score"` // From 0 (no risk) to 100
+	Triggers []string `json:"triggers"`
+	Messages []string `json:"messages,omitempty"`
+}
+
+// RiskScoreQuery represents available query parameters
+type RiskScoreQuery struct {
+	Before *time.Time `json:"before,omitempty"`
+	After  *time.Time `json:"after,omitempty"`
+}
+
+func (q *RiskScoreQuery) GetKeyValidators() map[string]func(interface{}) bool {
+	return map[string]func(interface{}) bool{
+		"before": validateTime,
+		"after":  validateTime,
+	}
+}
+
+// RiskScoreInsights counts the scored requests of a period by risk level
+type RiskScoreInsights struct {
+	Scores RiskScoreLevels `json:"scores"`
+	Total  int             `json:"total"`
+}
+
+// RiskScoreLevels holds the number of scores per risk level
+type RiskScoreLevels struct {
+	Minimal  int `json:"minimal"`
+	Low      int `json:"low"`
+	Medium   int `json:"medium"`
+	High     int `json:"high"`
+	VeryHigh int `json:"very_high"`
+}
@@ -23,3 +23,11 @@ func (r *Role) GetKeyValidators() map[string]func(interface{}) bool {
 		"cursor": validateString,
 	}
 }
+
+func (q *RoleQuery) GetKeyValidators() map[string]func(interface{}) bool {
+	return map[string]func(interface{}) bool{
+		"limit":  validateString,
+		"page":   validateString,
+		"cursor": validateString,
+	}
+}
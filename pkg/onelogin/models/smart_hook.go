@@ -77,6 +77,15 @@ type EnvVar struct {
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
 
+// HookLog represents the logs of one execution of a SmartHook
+type HookLog struct {
+	RequestID     string                 `json:"request_id"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	CreatedAt     *time.Time             `json:"created_at,omitempty"`
+	Logs          []string               `json:"logs"`
+	EventPayload  map[string]interface{} `json:"event_payload,omitempty"`
+}
+
 func (s *SmartHook) GetKeyValidators() map[string]func(interface{}) bool {
 	return map[string]func(interface{}) bool{
 		"limit":  validateString,
@@ -85,3 +94,12 @@ func (s *SmartHook) GetKeyValidators() map[string]func(interface{}) bool {
 		"type":   validateString,
 	}
 }
+
+func (q *SmartHookQuery) GetKeyValidators() map[string]func(interface{}) bool {
+	return map[string]func(interface{}) bool{
+		"limit":  validateString,
+		"page":   validateString,
+		"cursor": validateString,
+		"type":   validateString,
+	}
+}
@@ -78,7 +78,7 @@ type User struct {
 	TrustedIDPID         int32                  `json:"trusted_idp_id,omitempty"`
 	ManagerADID          int32                  `json:"manager_ad_id,omitempty"`
 	ManagerUserID        int32                  `json:"manager_user_id,omitempty"`
-	ExternalID           int32                  `json:"external_id,omitempty"`
+	ExternalID           string                 `json:"external_id,omitempty"`
 	ID                   int32                  `json:"id,omitempty"`
 	CustomAttributes     map[string]interface{} `json:"custom_attributes,omitempty"`
 }
@@ -110,6 +110,7 @@ func (q *UserQuery) GetKeyValidators() map[string]func(interface{}) bool {
 // UserApp is the contract for a users app.
 type UserApp struct {
 	ID                  *int32  `json:"id,omitempty"`
+	Name                *string `json:"name,omitempty"`
 	IconURL             *string `json:"icon_url,omitempty"`
 	LoginID             *int32  `json:"login_id,omitempty"`
 	ProvisioningStatus  *string `json:"provisioning_status,omitempty"`
@@ -48,3 +48,29 @@ func (u *UserMapping) GetKeyValidators() map[string]func(interface{}) bool {
 		"enabled":          validateBool,
 	}
 }
+
+func (q *UserMappingsQuery) GetKeyValidators() map[string]func(interface{}) bool {
+	return map[string]func(interface{}) bool{
+		"limit":              validateString,
+		"page":               validateString,
+		"cursor":             validateString,
+		"has_condition":      validateString,
+		"has_condition_type": validateString,
+		"has_action":         validateString,
+		"has_action_type":    validateString,
+		"enabled":            validateString,
+	}
+}
+
+// UserMappingOption is a condition, operator, action or value available to user mappings
+type UserMappingOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// UserMappingDryrun is a user matched by a mapping dry run, which reports the users a mapping
+// would affect without applying its actions.
+type UserMappingDryrun struct {
+	User   User `json:"user"`
+	Mapped bool `json:"mapped"`
+}
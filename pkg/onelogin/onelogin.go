@@ -1,57 +1,137 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
 	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
+
+const (
+	ConnectorsPath string = "api/2/connectors"
+	InvitesPath    string = "api/1/invites"
 )
 
 // OneloginSDK represents the Onelogin SDK.
+// It is safe for concurrent use by multiple goroutines; share one instance per tenant.
 type OneloginSDK struct {
 	Client *api.Client
+
+	// Paginated list endpoints, e.g. sdk.Users.List(ctx, query).All()
+	Users      *UsersService
+	Apps       *AppsService
+	Roles      *RolesService
+	Privileges *PrivilegesService
+	Mappings   *MappingsService
+	Hooks      *HooksService
+	Events     *EventsService
 }
 
 // NewOneloginSDK creates a new instance of the Onelogin SDK.
-func NewOneloginSDK() (*OneloginSDK, error) {
-	client, err := api.NewClient()
+// Without options it is configured from the ONELOGIN_* environment variables; with options only
+// the given settings are used, so pass api.FromEnv() first to fall back to the environment.
+func NewOneloginSDK(opts ...api.Option) (*OneloginSDK, error) {
+	if len(opts) == 0 {
+		return NewOneloginSDKWithConfig(api.ConfigFromEnv())
+	}
+	return NewOneloginSDKWithConfig(api.NewConfig(opts...))
+}
+
+// NewOneloginSDKWithConfig creates a new instance of the Onelogin SDK from an explicit configuration.
+func NewOneloginSDKWithConfig(cfg api.Config) (*OneloginSDK, error) {
+	client, err := api.NewClientWithConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
-	return &OneloginSDK{Client: client}, nil
+	return NewOneloginSDKWithClient(client), nil
+}
+
+// NewOneloginSDKWithClient creates a new instance of the Onelogin SDK around an existing API client.
+func NewOneloginSDKWithClient(client *api.Client) *OneloginSDK {
+	sdk := &OneloginSDK{Client: client}
+	sdk.Users = &UsersService{sdk: sdk}
+	sdk.Apps = &AppsService{sdk: sdk}
+	sdk.Roles = &RolesService{sdk: sdk}
+	sdk.Privileges = &PrivilegesService{sdk: sdk}
+	sdk.Mappings = &MappingsService{sdk: sdk}
+	sdk.Hooks = &HooksService{sdk: sdk}
+	sdk.Events = &EventsService{sdk: sdk}
+	return sdk
 }
 
 // GetToken performs the authentication process using the env credentials.
 func (sdk *OneloginSDK) GetToken() (string, error) {
 	// Call the authenticator to perform the authentication process
-	accessTk, err := sdk.Client.Auth.GetToken()
+	accessTk, err := sdk.Client.Auth.Token()
 	if err != nil {
-		return "", olerror.NewSDKError("Access Token retrieval unsuccessful")
+		return "", olerror.WrapSDKError("Access Token retrieval unsuccessful", err)
 	}
-	return accessTk, nil
+	return accessTk.AccessToken, nil
+}
+
+// Token returns the current access token together with its type, expiry and refresh token.
+func (sdk *OneloginSDK) Token() (*authentication.Token, error) {
+	return sdk.Client.Auth.Token()
+}
+
+// Close revokes the current access token and clears the SDK's cached authentication state.
+func (sdk *OneloginSDK) Close(ctx context.Context) error {
+	return sdk.Client.Close(ctx)
 }
 
-func (sdk *OneloginSDK) GenerateInviteLink(email string) (interface{}, error) {
-	p := "api/1/invites/get_invite_link"
-	resp, err := sdk.Client.Post(&p, email)
+// GenerateInviteLink returns a link the user with the given email can follow to set their password.
+func (sdk *OneloginSDK) GenerateInviteLink(email string) (string, error) {
+	return sdk.GenerateInviteLinkWithContext(context.Background(), email)
+}
+
+func (sdk *OneloginSDK) GenerateInviteLinkWithContext(ctx context.Context, email string) (string, error) {
+	p, err := utl.BuildAPIPath(InvitesPath, "get_invite_link")
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	return resp, nil
+	// API v1 returns the link as a single element list.
+	links, _, err := api.Do[[]string](ctx, sdk.Client, http.MethodPost, p, nil, mod.GenerateInviteLinkRequest{Email: email})
+	if err != nil {
+		return "", err
+	}
+	if len(links) == 0 {
+		return "", olerror.NewSDKError("no invite link in the response")
+	}
+	return links[0], nil
 }
 
-func (sdk *OneloginSDK) ListConnectors() (interface{}, error) {
-	p := "api/2/connectors"
-	resp, err := sdk.Client.Get(&p, nil)
+func (sdk *OneloginSDK) ListConnectors() ([]mod.Connector, error) {
+	return sdk.ListConnectorsWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) ListConnectorsWithContext(ctx context.Context) ([]mod.Connector, error) {
+	p, err := utl.BuildAPIPath(ConnectorsPath)
 	if err != nil {
 		return nil, err
 	}
-	return resp, nil
+	connectors, _, err := api.Do[[]mod.Connector](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return connectors, err
+}
+
+// SendInviteLink emails the invite link of the user with invite.Email, to invite.PersonalEmail when set.
+func (sdk *OneloginSDK) SendInviteLink(invite mod.SendInviteLinkRequest) error {
+	return sdk.SendInviteLinkWithContext(context.Background(), invite)
 }
 
-func (sdk *OneloginSDK) SendInviteLink(email string) (interface{}, error) {
-	p := "api/1/invites/send_invite_link"
-	resp, err := sdk.Client.Post(&p, email)
+func (sdk *OneloginSDK) SendInviteLinkWithContext(ctx context.Context, invite mod.SendInviteLinkRequest) error {
+	p, err := utl.BuildAPIPath(InvitesPath, "send_invite_link")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return resp, nil
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPost, p, nil, invite)
+	return err
+}
+
+// RateLimit returns the most recent rate limit budget reported by OneLogin, if any.
+func (sdk *OneloginSDK) RateLimit() (api.RateLimit, bool) {
+	return sdk.Client.RateLimit()
 }
@@ -0,0 +1,248 @@
+package onelogintest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+)
+
+// Mode selects whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette and never contacts the network.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests through the underlying client and records them.
+	ModeRecord
+	// ModeAuto replays the cassette when its file exists and records it otherwise.
+	ModeAuto
+)
+
+// Cassette is the list of interactions stored in a cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response, with secrets scrubbed.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the scrubbed form of a request.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"` // Query string with its keys sorted
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"` // Body with secrets redacted and JSON keys sorted
+}
+
+// RecordedResponse is the scrubbed form of a response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an api.HTTPClient recording OneLogin interactions to a cassette file and replaying
+// them deterministically. Recorded requests and responses have their Authorization and cookie
+// headers, passwords, client secrets, tokens and environment variable values replaced by
+// api.Redacted, as in api.RedactHeader and api.RedactBody. Requests are matched on their method,
+// path, query and scrubbed body, and each interaction is replayed once, in recording order.
+// It is safe for concurrent use.
+type Recorder struct {
+	// Scrub, when set, is applied to every interaction before it is stored, e.g. to mask email
+	// addresses. Replayed requests are matched against the scrubbed recording.
+	Scrub func(*Interaction)
+
+	path   string
+	mode   Mode
+	client api.HTTPClient
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// ErrNoInteraction is returned when a replayed request matches no unused recorded interaction.
+var ErrNoInteraction = errors.New("onelogintest: no recorded interaction matches the request")
+
+// NewRecorder returns a Recorder for the cassette file at path. In record mode requests are sent
+// with client, http.DefaultClient when nil, and Save writes them to path. In replay mode the
+// cassette is loaded from path.
+func NewRecorder(path string, mode Mode, client api.HTTPClient) (*Recorder, error) {
+	if mode == ModeAuto {
+		mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = ModeReplay
+		}
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r := &Recorder{path: path, mode: mode, client: client}
+	if mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, fmt.Errorf("onelogintest: invalid cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns whether the recorder records or replays.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Cassette returns a copy of the recorded or loaded interactions.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Do records or replays a request.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Header: api.RedactHeader(req.Header),
+		Body:   api.RedactBody(req.URL.Path, body),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := api.ReadResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	interaction := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     api.RedactHeader(resp.Header),
+			Body:       scrubResponseBody(req.URL.Path, respBody),
+		},
+	}
+	if r.Scrub != nil {
+		r.Scrub(&interaction)
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	if r.Scrub != nil {
+		interaction := Interaction{Request: recorded}
+		r.Scrub(&interaction)
+		recorded = interaction.Request
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matchRequest(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+		rec := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+			StatusCode:    rec.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        rec.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(rec.Body)),
+			ContentLength: int64(len(rec.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+}
+
+// Save writes the recorded interactions to the cassette file, creating its directory if needed.
+// It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+	r.mu.Lock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o600)
+}
+
+// Unused returns the recorded interactions that have not been replayed yet.
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if i < len(r.used) && !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+func matchRequest(recorded, req RecordedRequest) bool {
+	return recorded.Method == req.Method && recorded.Path == req.Path &&
+		recorded.Query == req.Query && recorded.Body == req.Body
+}
+
+// scrubResponseBody redacts the secrets of a JSON response body and keeps other bodies as is.
+func scrubResponseBody(path string, body []byte) string {
+	if !json.Valid(body) {
+		return string(body)
+	}
+	return api.RedactBody(path, body)
+}
+
+// readRequestBody returns the body of req and replaces it so it can still be sent.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.GetBody != nil {
+		return api.ReadRequestBody(req)
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	return b, err
+}
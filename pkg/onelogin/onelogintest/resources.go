@@ -0,0 +1,435 @@
+package onelogintest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+// Resource names a collection emulated by a Server.
+type Resource string
+
+const (
+	Users      Resource = "users"
+	Roles      Resource = "roles"
+	Apps       Resource = "apps"
+	Privileges Resource = "privileges"
+	Mappings   Resource = "mappings"
+	Hooks      Resource = "hooks"
+)
+
+var resources = []Resource{Users, Roles, Apps, Privileges, Mappings, Hooks}
+
+// resourceSpec describes how a collection is served.
+type resourceSpec struct {
+	path      string   // Collection path
+	stringIDs bool     // Whether ids are encoded as JSON strings, as the models of the resource expect
+	required  []string // Fields a created resource must have; any one of them is enough
+}
+
+var specs = map[Resource]resourceSpec{
+	Users:      {path: "/api/2/users", required: []string{"email", "username"}},
+	Roles:      {path: "/api/2/roles", required: []string{"name"}},
+	Apps:       {path: "/api/2/apps", required: []string{"name"}},
+	Privileges: {path: "/api/1/privileges", stringIDs: true, required: []string{"name"}},
+	Mappings:   {path: "/api/2/mappings", required: []string{"name"}},
+	Hooks:      {path: "/api/2/hooks", stringIDs: true, required: []string{"type"}},
+}
+
+const (
+	// DefaultPageSize is the number of items listed per page when the request gives no limit.
+	DefaultPageSize int = 50
+	// MaxPageSize is the largest page size accepted.
+	MaxPageSize int = 1000
+)
+
+// collection holds the resources of one kind as generic JSON objects in creation order.
+type collection struct {
+	spec   resourceSpec
+	nextID int
+	order  []string
+	items  map[string]map[string]interface{}
+}
+
+func newCollection(r Resource) *collection {
+	return &collection{spec: specs[r], nextID: 1, items: map[string]map[string]interface{}{}}
+}
+
+// create stores item under a new id and returns it.
+func (c *collection) create(item map[string]interface{}) map[string]interface{} {
+	n := c.nextID
+	c.nextID++
+	key := strconv.Itoa(n)
+	if c.spec.stringIDs {
+		item["id"] = key
+	} else {
+		item["id"] = n
+	}
+	c.order = append(c.order, key)
+	c.items[key] = item
+	return item
+}
+
+func (c *collection) delete(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	for i, key := range c.order {
+		if key == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// list returns the items whose fields match every filter, in creation order.
+func (c *collection) list(filters url.Values) []map[string]interface{} {
+	items := []map[string]interface{}{}
+	for _, key := range c.order {
+		if matches(c.items[key], filters) {
+			items = append(items, c.items[key])
+		}
+	}
+	return items
+}
+
+// paginationParams are query parameters that do not filter list results.
+var paginationParams = map[string]bool{"limit": true, "page": true, "cursor": true, "fields": true, "sort": true}
+
+// matches reports whether the fields of item equal the filters. The user_ids filter takes a comma
+// separated list of ids, and the time range filters ending in _since and _until are ignored.
+func matches(item map[string]interface{}, filters url.Values) bool {
+	for key, values := range filters {
+		if paginationParams[key] || len(values) == 0 || values[0] == "" ||
+			strings.HasSuffix(key, "_since") || strings.HasSuffix(key, "_until") {
+			continue
+		}
+		if key == "user_ids" {
+			if !contains(strings.Split(values[0], ","), fmt.Sprint(item["id"])) {
+				return false
+			}
+			continue
+		}
+		value, ok := item[key]
+		if !ok || fmt.Sprint(value) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.TrimSpace(v) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Add stores v, typically a model such as mod.User, as a new resource and returns it with the
+// id assigned by the server.
+func Add[T any](s *Server, r Resource, v T) (T, error) {
+	var out T
+	b, err := json.Marshal(v)
+	if err != nil {
+		return out, err
+	}
+	var item map[string]interface{}
+	if err := json.Unmarshal(b, &item); err != nil {
+		return out, err
+	}
+
+	s.mu.Lock()
+	c, ok := s.collections[r]
+	if ok {
+		item = c.create(item)
+		b, err = json.Marshal(item)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return out, fmt.Errorf("onelogintest: unknown resource %q", r)
+	}
+	if err != nil {
+		return out, err
+	}
+	err = json.Unmarshal(b, &out)
+	return out, err
+}
+
+// AddUser stores a user and returns it with its id.
+func (s *Server) AddUser(user mod.User) (mod.User, error) { return Add(s, Users, user) }
+
+// AddRole stores a role and returns it with its id.
+func (s *Server) AddRole(role mod.Role) (mod.Role, error) { return Add(s, Roles, role) }
+
+// AddApp stores an app and returns it with its id.
+func (s *Server) AddApp(app mod.App) (mod.App, error) { return Add(s, Apps, app) }
+
+// AddPrivilege stores a privilege and returns it with its id.
+func (s *Server) AddPrivilege(privilege mod.Privilege) (mod.Privilege, error) {
+	return Add(s, Privileges, privilege)
+}
+
+// AddMapping stores a user mapping and returns it with its id.
+func (s *Server) AddMapping(mapping mod.UserMapping) (mod.UserMapping, error) {
+	return Add(s, Mappings, mapping)
+}
+
+// AddHook stores a smart hook and returns it with its id.
+func (s *Server) AddHook(hook mod.SmartHook) (mod.SmartHook, error) { return Add(s, Hooks, hook) }
+
+// Len returns the number of resources of kind r.
+func (s *Server) Len(r Resource) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.collections[r]; ok {
+		return len(c.items)
+	}
+	return 0
+}
+
+// route serves an authenticated API request.
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	for _, res := range resources {
+		c := s.collections[res]
+		if r.URL.Path != c.spec.path && !strings.HasPrefix(r.URL.Path, c.spec.path+"/") {
+			continue
+		}
+		rest := strings.Trim(strings.TrimPrefix(r.URL.Path, c.spec.path), "/")
+		var segments []string
+		if rest != "" {
+			segments = strings.Split(rest, "/")
+		}
+		switch {
+		case len(segments) == 0:
+			s.serveCollection(w, r, c)
+		case len(segments) == 1:
+			s.serveItem(w, r, c, segments[0])
+		case len(segments) == 2 && res == Roles:
+			s.serveRoleMembers(w, r, segments[0], segments[1])
+		default:
+			writeError(w, http.StatusNotFound, "Not Found", nil)
+		}
+		return
+	}
+	writeError(w, http.StatusNotFound, "Not Found", nil)
+}
+
+func (s *Server) serveCollection(w http.ResponseWriter, r *http.Request, c *collection) {
+	switch r.Method {
+	case http.MethodGet:
+		writePage(w, r, c.list(r.URL.Query()))
+	case http.MethodPost:
+		item, ok := decodeObject(w, r)
+		if !ok || !validate(w, item, c.spec.required) {
+			return
+		}
+		writeJSON(w, http.StatusCreated, c.create(item))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", nil)
+	}
+}
+
+func (s *Server) serveItem(w http.ResponseWriter, r *http.Request, c *collection, id string) {
+	item, ok := c.items[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found", nil)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, item)
+	case http.MethodPut, http.MethodPatch:
+		changes, ok := decodeObject(w, r)
+		if !ok {
+			return
+		}
+		for key, value := range changes {
+			if key != "id" {
+				item[key] = value
+			}
+		}
+		writeJSON(w, http.StatusOK, item)
+	case http.MethodDelete:
+		c.delete(id)
+		if c == s.collections[Roles] {
+			delete(s.members, id)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", nil)
+	}
+}
+
+// serveRoleMembers serves the users, admins and apps of a role. Members are given as a JSON
+// array of ids and listed as the full user or app objects.
+func (s *Server) serveRoleMembers(w http.ResponseWriter, r *http.Request, roleID, kind string) {
+	members := s.collections[Users]
+	if kind == "apps" {
+		members = s.collections[Apps]
+	} else if kind != "users" && kind != "admins" {
+		writeError(w, http.StatusNotFound, "Not Found", nil)
+		return
+	}
+	if _, ok := s.collections[Roles].items[roleID]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found", nil)
+		return
+	}
+	if s.members[roleID] == nil {
+		s.members[roleID] = map[string][]int{}
+	}
+	current := s.members[roleID][kind]
+
+	if r.Method == http.MethodGet {
+		items := []map[string]interface{}{}
+		for _, id := range current {
+			if item, ok := members.items[strconv.Itoa(id)]; ok && matches(item, r.URL.Query()) {
+				items = append(items, item)
+			}
+		}
+		writePage(w, r, items)
+		return
+	}
+
+	var ids []int
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+			writeError(w, http.StatusBadRequest, "Expected a JSON array of ids", nil)
+			return
+		}
+	}
+	for _, id := range ids {
+		if _, ok := members.items[strconv.Itoa(id)]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%d Not Found", id), nil)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		current = union(current, ids)
+	case http.MethodPut:
+		if kind == "apps" {
+			current = union(nil, ids)
+		} else {
+			current = union(current, ids)
+		}
+	case http.MethodDelete:
+		current = difference(current, ids)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", nil)
+		return
+	}
+	s.members[roleID][kind] = current
+
+	result := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		result[i] = map[string]interface{}{"id": id}
+	}
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func union(ids, add []int) []int {
+	set := map[int]bool{}
+	for _, id := range append(append([]int(nil), ids...), add...) {
+		set[id] = true
+	}
+	out := make([]int, 0, len(set))
+	for id := range set {
+		out = append(out, id)
+	}
+	sort.Ints(out)
+	return out
+}
+
+func difference(ids, remove []int) []int {
+	drop := map[int]bool{}
+	for _, id := range remove {
+		drop[id] = true
+	}
+	var out []int
+	for _, id := range ids {
+		if !drop[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// writePage writes the page of items selected by the limit and page query parameters, with the
+// Total-Count, Total-Pages, Current-Page and Link headers of the OneLogin API.
+func writePage(w http.ResponseWriter, r *http.Request, items []map[string]interface{}) {
+	query := r.URL.Query()
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		limit = MaxPageSize
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	pages := (len(items) + limit - 1) / limit
+	if pages == 0 {
+		pages = 1
+	}
+
+	start := (page - 1) * limit
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	w.Header().Set(api.TotalCountHeader, strconv.Itoa(len(items)))
+	w.Header().Set(api.TotalPagesHeader, strconv.Itoa(pages))
+	w.Header().Set(api.CurrentPageHeader, strconv.Itoa(page))
+	if page < pages {
+		next := *r.URL
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		w.Header().Set(api.LinkHeader, fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+	writeJSON(w, http.StatusOK, items[start:end])
+}
+
+// decodeObject decodes a JSON object request body, answering 400 Bad Request when it is not one.
+func decodeObject(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	var item map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil || item == nil {
+		writeError(w, http.StatusBadRequest, "Expected a JSON object", nil)
+		return nil, false
+	}
+	return item, true
+}
+
+// validate answers 422 Unprocessable Entity when item has none of the required fields.
+func validate(w http.ResponseWriter, item map[string]interface{}, required []string) bool {
+	for _, field := range required {
+		if v, ok := item[field]; ok && v != nil && v != "" {
+			return true
+		}
+	}
+	writeError(w, http.StatusUnprocessableEntity, "Validation Failed", []map[string]interface{}{
+		{"field": strings.Join(required, " or "), "message": []string{"must be present"}},
+	})
+	return false
+}
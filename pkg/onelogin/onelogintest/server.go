@@ -0,0 +1,233 @@
+// Package onelogintest provides an in-memory fake of the OneLogin API for tests.
+//
+// A Server emulates the token endpoint and stateful users, roles, apps, privileges, user mappings
+// and smart hooks endpoints, with pagination, 404s for unknown resources and injectable failures
+// such as 429 rate limit responses:
+//
+//	server := onelogintest.NewServer()
+//	defer server.Close()
+//
+//	sdk, err := server.SDK()
+//	user, err := sdk.CreateUser(models.User{Email: "jane@example.com"})
+package onelogintest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
+)
+
+const (
+	ClientID     string = "onelogintest-client-id"
+	ClientSecret string = "onelogintest-client-secret"
+
+	// TokenLifetime is the lifetime of the access tokens issued by a Server.
+	TokenLifetime time.Duration = 10 * time.Hour
+	// RateLimit is the request budget reported in the X-RateLimit-* headers.
+	RateLimit int = 5000
+)
+
+// Server is an in-memory OneLogin API served by an httptest.Server.
+// It is safe for concurrent use by multiple goroutines.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	collections map[Resource]*collection
+	members     map[string]map[string][]int // role id -> "users", "admins" or "apps" -> ids
+	tokens      map[string]bool
+	tokenCount  int
+	requests    []string
+	failures    []failure
+}
+
+// failure is an injected response returned instead of handling a request.
+type failure struct {
+	status     int
+	retryAfter time.Duration
+}
+
+// NewServer starts a Server with no resources. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		collections: map[Resource]*collection{},
+		members:     map[string]map[string][]int{},
+		tokens:      map[string]bool{},
+	}
+	for _, r := range resources {
+		s.collections[r] = newCollection(r)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Config returns a configuration pointing at the server with valid credentials.
+func (s *Server) Config() api.Config {
+	return api.Config{
+		BaseURL:      s.URL,
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+		HTTPClient:   s.Client(),
+	}
+}
+
+// SDK returns an SDK authenticated against the server.
+func (s *Server) SDK() (*onelogin.OneloginSDK, error) {
+	return onelogin.NewOneloginSDKWithConfig(s.Config())
+}
+
+// FailNext makes the next n API requests fail with status before they reach the fake resources.
+// Token requests are not affected.
+func (s *Server) FailNext(n int, status int) {
+	s.inject(n, failure{status: status})
+}
+
+// RateLimitNext makes the next n API requests fail with 429 Too Many Requests, an exhausted
+// rate limit budget and a Retry-After header of retryAfter, rounded down to the second.
+func (s *Server) RateLimitNext(n int, retryAfter time.Duration) {
+	s.inject(n, failure{status: http.StatusTooManyRequests, retryAfter: retryAfter})
+}
+
+func (s *Server) inject(n int, f failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, f)
+	}
+}
+
+// ExpireTokens invalidates every access token issued so far, so the next API request is
+// rejected with 401 Unauthorized until a new token is generated.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = map[string]bool{}
+}
+
+// Requests returns the requests received so far as "METHOD /path", token requests included.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	w.Header().Set(api.RequestIDHeader, fmt.Sprintf("req-%d", len(s.requests)))
+
+	switch r.URL.Path {
+	case authentication.TkPath:
+		s.serveToken(w, r)
+		return
+	case authentication.RevokePath:
+		s.serveRevoke(w, r)
+		return
+	}
+
+	if !s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+		writeError(w, http.StatusUnauthorized, "Authentication Failure", nil)
+		return
+	}
+
+	remaining := RateLimit - len(s.requests)%RateLimit
+	if len(s.failures) > 0 {
+		f := s.failures[0]
+		s.failures = s.failures[1:]
+		if f.status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", strconv.Itoa(int(f.retryAfter/time.Second)))
+			remaining = 0
+		}
+		setRateLimit(w, remaining)
+		writeError(w, f.status, http.StatusText(f.status), nil)
+		return
+	}
+	setRateLimit(w, remaining)
+	s.route(w, r)
+}
+
+func setRateLimit(w http.ResponseWriter, remaining int) {
+	w.Header().Set(api.RateLimitLimitHeader, strconv.Itoa(RateLimit))
+	w.Header().Set(api.RateLimitRemainingHeader, strconv.Itoa(remaining))
+	w.Header().Set(api.RateLimitResetHeader, "60")
+}
+
+// credentials reports whether the request carries the server's client credentials.
+func credentials(r *http.Request) bool {
+	expected := base64.StdEncoding.EncodeToString([]byte(ClientID + ":" + ClientSecret))
+	return r.Header.Get("Authorization") == "Basic "+expected
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed", nil)
+		return
+	}
+	if !credentials(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"status": map[string]interface{}{"error": true, "code": 401, "type": "Unauthorized", "message": "Authentication Failure"},
+		})
+		return
+	}
+	s.tokenCount++
+	token := fmt.Sprintf("onelogintest-token-%d", s.tokenCount)
+	s.tokens[token] = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token":  token,
+		"refresh_token": fmt.Sprintf("onelogintest-refresh-%d", s.tokenCount),
+		"token_type":    "bearer",
+		"account_id":    1,
+		"created_at":    time.Now().UTC().Format(time.RFC3339),
+		"expires_in":    int(TokenLifetime / time.Second),
+	})
+}
+
+func (s *Server) serveRevoke(w http.ResponseWriter, r *http.Request) {
+	if !credentials(r) {
+		writeError(w, http.StatusUnauthorized, "Authentication Failure", nil)
+		return
+	}
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON", nil)
+		return
+	}
+	delete(s.tokens, body.AccessToken)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": map[string]interface{}{"error": false, "code": 200, "type": "success", "message": "Success"},
+	})
+}
+
+// writeJSON writes v as the JSON body of a response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an API v2 error body.
+func writeError(w http.ResponseWriter, status int, message string, fieldErrors []map[string]interface{}) {
+	body := map[string]interface{}{
+		"statusCode": status,
+		"name":       strings.ReplaceAll(http.StatusText(status), " ", "") + "Error",
+		"message":    message,
+	}
+	if fieldErrors != nil {
+		body["errors"] = fieldErrors
+	}
+	writeJSON(w, status, body)
+}
@@ -0,0 +1,133 @@
+package onelogin
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
+
+// UsersService iterates over users.
+type UsersService struct {
+	sdk *OneloginSDK
+}
+
+// List returns a Pager over the users matching query. A nil query lists all users.
+func (s *UsersService) List(ctx context.Context, query *mod.UserQuery) *api.Pager[mod.User] {
+	var q mod.UserQuery
+	if query != nil {
+		q = *query
+	}
+	return listPages[mod.User](ctx, s.sdk.Client, UserPathV2, &q, &q.Cursor, &q.Page)
+}
+
+// AppsService iterates over apps.
+type AppsService struct {
+	sdk *OneloginSDK
+}
+
+// List returns a Pager over the apps matching query. A nil query lists all apps.
+func (s *AppsService) List(ctx context.Context, query *mod.AppQuery) *api.Pager[mod.App] {
+	var q mod.AppQuery
+	if query != nil {
+		q = *query
+	}
+	return listPages[mod.App](ctx, s.sdk.Client, AppPath, &q, &q.Cursor, &q.Page)
+}
+
+// RolesService iterates over roles.
+type RolesService struct {
+	sdk *OneloginSDK
+}
+
+// List returns a Pager over the roles matching query. A nil query lists all roles.
+func (s *RolesService) List(ctx context.Context, query *mod.RoleQuery) *api.Pager[mod.Role] {
+	var q mod.RoleQuery
+	if query != nil {
+		q = *query
+	}
+	return listPages[mod.Role](ctx, s.sdk.Client, RolePath, &q, &q.Cursor, &q.Page)
+}
+
+// PrivilegesService iterates over privileges.
+type PrivilegesService struct {
+	sdk *OneloginSDK
+}
+
+// List returns a Pager over the privileges matching query. A nil query lists all privileges.
+func (s *PrivilegesService) List(ctx context.Context, query *mod.PrivilegeQuery) *api.Pager[mod.Privilege] {
+	var q mod.PrivilegeQuery
+	if query != nil {
+		q = *query
+	}
+	return listPages[mod.Privilege](ctx, s.sdk.Client, PrivilegesPath, &q, &q.Cursor, &q.Page)
+}
+
+// MappingsService iterates over user mappings.
+type MappingsService struct {
+	sdk *OneloginSDK
+}
+
+// List returns a Pager over the user mappings matching query. A nil query lists all mappings.
+func (s *MappingsService) List(ctx context.Context, query *mod.UserMappingsQuery) *api.Pager[mod.UserMapping] {
+	var q mod.UserMappingsQuery
+	if query != nil {
+		q = *query
+	}
+	return listPages[mod.UserMapping](ctx, s.sdk.Client, MappingsPath, &q, &q.Cursor, &q.Page)
+}
+
+// HooksService iterates over smart hooks.
+type HooksService struct {
+	sdk *OneloginSDK
+}
+
+// List returns a Pager over the smart hooks matching query. A nil query lists all hooks.
+func (s *HooksService) List(ctx context.Context, query *mod.SmartHookQuery) *api.Pager[mod.SmartHook] {
+	var q mod.SmartHookQuery
+	if query != nil {
+		q = *query
+	}
+	return listPages[mod.SmartHook](ctx, s.sdk.Client, SmartHooksPath, &q, &q.Cursor, &q.Page)
+}
+
+// EventsService iterates over events.
+type EventsService struct {
+	sdk *OneloginSDK
+}
+
+// List returns a Pager over the events matching query, following the after_cursor of each page.
+// A nil query lists all events.
+func (s *EventsService) List(ctx context.Context, query *mod.EventQuery) *api.Pager[mod.Event] {
+	var q mod.EventQuery
+	if query != nil {
+		q = *query
+	}
+	return api.NewPager(ctx, func(ctx context.Context, req api.PageRequest) ([]mod.Event, *api.Response, error) {
+		if req.Cursor != "" {
+			q.Cursor = req.Cursor
+		}
+		return listEvents(ctx, s.sdk.Client, &q)
+	})
+}
+
+// listPages returns a Pager issuing GET requests to path with query, pointing its cursor and
+// page fields at each requested page in turn.
+func listPages[T any](ctx context.Context, client *api.Client, path string, query mod.Queryable, cursor, page *string) *api.Pager[T] {
+	return api.NewPager(ctx, func(ctx context.Context, req api.PageRequest) ([]T, *api.Response, error) {
+		p, err := utl.BuildAPIPath(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case req.Cursor != "":
+			*cursor, *page = req.Cursor, ""
+		case req.Page > 0:
+			*cursor, *page = "", strconv.Itoa(req.Page)
+		}
+		return api.Do[[]T](ctx, client, http.MethodGet, p, query, nil)
+	})
+}
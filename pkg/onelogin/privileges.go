@@ -1,6 +1,10 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -9,134 +13,153 @@ const (
 	PrivilegesPath string = "api/1/privileges"
 )
 
-func (sdk *OneloginSDK) ListPrivileges() (interface{}, error) {
+func (sdk *OneloginSDK) ListPrivileges() ([]models.Privilege, error) {
+	return sdk.ListPrivilegesWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) ListPrivilegesWithContext(ctx context.Context) ([]models.Privilege, error) {
 	p, err := utl.BuildAPIPath(PrivilegesPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	privileges, _, err := api.Do[[]models.Privilege](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return privileges, err
+}
+
+func (sdk *OneloginSDK) CreatePrivilege(privilege models.Privilege) (*models.Privilege, error) {
+	return sdk.CreatePrivilegeWithContext(context.Background(), privilege)
 }
 
-func (sdk *OneloginSDK) CreatePrivilege(privilege models.Privilege) (interface{}, error) {
+func (sdk *OneloginSDK) CreatePrivilegeWithContext(ctx context.Context, privilege models.Privilege) (*models.Privilege, error) {
 	p, err := utl.BuildAPIPath(PrivilegesPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, privilege)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*models.Privilege](ctx, sdk.Client, http.MethodPost, p, nil, privilege)
+	return created, err
 }
 
-func (sdk *OneloginSDK) GetPrivilege(privilegeID int) (interface{}, error) {
+func (sdk *OneloginSDK) GetPrivilege(privilegeID int) (*models.Privilege, error) {
+	return sdk.GetPrivilegeWithContext(context.Background(), privilegeID)
+}
+
+func (sdk *OneloginSDK) GetPrivilegeWithContext(ctx context.Context, privilegeID int) (*models.Privilege, error) {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	privilege, _, err := api.Do[*models.Privilege](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return privilege, err
+}
+
+func (sdk *OneloginSDK) DeletePrivilege(privilegeID int) error {
+	return sdk.DeletePrivilegeWithContext(context.Background(), privilegeID)
 }
 
-func (sdk *OneloginSDK) DeletePrivilege(privilegeID int) (interface{}, error) {
+func (sdk *OneloginSDK) DeletePrivilegeWithContext(ctx context.Context, privilegeID int) error {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+func (sdk *OneloginSDK) UpdatePrivilege(privilegeID int) (*models.Privilege, error) {
+	return sdk.UpdatePrivilegeWithContext(context.Background(), privilegeID)
 }
 
-func (sdk *OneloginSDK) UpdatePrivilege(privilegeID int) (interface{}, error) {
+func (sdk *OneloginSDK) UpdatePrivilegeWithContext(ctx context.Context, privilegeID int) (*models.Privilege, error) {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*models.Privilege](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return updated, err
 }
 
-func (sdk *OneloginSDK) GetPrivilegeUsers(privilegeID int) (interface{}, error) {
+// GetPrivilegeUsers returns the ids of the users the privilege is assigned to.
+func (sdk *OneloginSDK) GetPrivilegeUsers(privilegeID int) ([]int, error) {
+	return sdk.GetPrivilegeUsersWithContext(context.Background(), privilegeID)
+}
+
+func (sdk *OneloginSDK) GetPrivilegeUsersWithContext(ctx context.Context, privilegeID int) ([]int, error) {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID, "users")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
+	result, _, err := api.Do[*models.PrivilegeAssignments](ctx, sdk.Client, http.MethodGet, p, nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	return result.Users, nil
+}
+
+func (sdk *OneloginSDK) AssignUsersToPrivilege(privilegeID int) error {
+	return sdk.AssignUsersToPrivilegeWithContext(context.Background(), privilegeID)
 }
 
-func (sdk *OneloginSDK) AssignUsersToPrivilege(privilegeID int) (interface{}, error) {
+func (sdk *OneloginSDK) AssignUsersToPrivilegeWithContext(ctx context.Context, privilegeID int) error {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID, "users")
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) RemovePrivilegeFromUser(privilegeID int, userID int) (interface{}, error) {
+func (sdk *OneloginSDK) RemovePrivilegeFromUser(privilegeID int, userID int) error {
+	return sdk.RemovePrivilegeFromUserWithContext(context.Background(), privilegeID, userID)
+}
+
+func (sdk *OneloginSDK) RemovePrivilegeFromUserWithContext(ctx context.Context, privilegeID int, userID int) error {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID, "users", userID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) GetPrivilegeRoles(privilegeID int) (interface{}, error) {
+// GetPrivilegeRoles returns the ids of the roles the privilege is assigned to.
+func (sdk *OneloginSDK) GetPrivilegeRoles(privilegeID int) ([]int, error) {
+	return sdk.GetPrivilegeRolesWithContext(context.Background(), privilegeID)
+}
+
+func (sdk *OneloginSDK) GetPrivilegeRolesWithContext(ctx context.Context, privilegeID int) ([]int, error) {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID, "roles")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
+	result, _, err := api.Do[*models.PrivilegeAssignments](ctx, sdk.Client, http.MethodGet, p, nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	return result.Roles, nil
+}
+
+func (sdk *OneloginSDK) AddPrivilegeToRole(privilegeID int, roleID int) error {
+	return sdk.AddPrivilegeToRoleWithContext(context.Background(), privilegeID, roleID)
 }
 
-func (sdk *OneloginSDK) AddPrivilegeToRole(privilegeID int, roleID int) (interface{}, error) {
+func (sdk *OneloginSDK) AddPrivilegeToRoleWithContext(ctx context.Context, privilegeID int, roleID int) error {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID, "roles", roleID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) DeleteRoleFromPrivilege(privilegeID int, roleID int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteRoleFromPrivilege(privilegeID int, roleID int) error {
+	return sdk.DeleteRoleFromPrivilegeWithContext(context.Background(), privilegeID, roleID)
+}
+
+func (sdk *OneloginSDK) DeleteRoleFromPrivilegeWithContext(ctx context.Context, privilegeID int, roleID int) error {
 	p, err := utl.BuildAPIPath(PrivilegesPath, privilegeID, "roles", roleID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
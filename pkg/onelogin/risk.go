@@ -0,0 +1,129 @@
+package onelogin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
+
+const (
+	RiskPath string = "api/2/risk"
+)
+
+func (sdk *OneloginSDK) ListRiskRules() ([]mod.RiskRule, error) {
+	return sdk.ListRiskRulesWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) ListRiskRulesWithContext(ctx context.Context) ([]mod.RiskRule, error) {
+	p, err := utl.BuildAPIPath(RiskPath, "rules")
+	if err != nil {
+		return nil, err
+	}
+	rules, _, err := api.Do[[]mod.RiskRule](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return rules, err
+}
+
+func (sdk *OneloginSDK) CreateRiskRule(rule mod.RiskRule) (*mod.RiskRule, error) {
+	return sdk.CreateRiskRuleWithContext(context.Background(), rule)
+}
+
+func (sdk *OneloginSDK) CreateRiskRuleWithContext(ctx context.Context, rule mod.RiskRule) (*mod.RiskRule, error) {
+	p, err := utl.BuildAPIPath(RiskPath, "rules")
+	if err != nil {
+		return nil, err
+	}
+	created, _, err := api.Do[*mod.RiskRule](ctx, sdk.Client, http.MethodPost, p, nil, rule)
+	return created, err
+}
+
+func (sdk *OneloginSDK) GetRiskRule(ruleID string) (*mod.RiskRule, error) {
+	return sdk.GetRiskRuleWithContext(context.Background(), ruleID)
+}
+
+func (sdk *OneloginSDK) GetRiskRuleWithContext(ctx context.Context, ruleID string) (*mod.RiskRule, error) {
+	p, err := utl.BuildAPIPath(RiskPath, "rules", ruleID)
+	if err != nil {
+		return nil, err
+	}
+	rule, _, err := api.Do[*mod.RiskRule](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return rule, err
+}
+
+func (sdk *OneloginSDK) UpdateRiskRule(ruleID string, rule mod.RiskRule) (*mod.RiskRule, error) {
+	return sdk.UpdateRiskRuleWithContext(context.Background(), ruleID, rule)
+}
+
+func (sdk *OneloginSDK) UpdateRiskRuleWithContext(ctx context.Context, ruleID string, rule mod.RiskRule) (*mod.RiskRule, error) {
+	p, err := utl.BuildAPIPath(RiskPath, "rules", ruleID)
+	if err != nil {
+		return nil, err
+	}
+	updated, _, err := api.Do[*mod.RiskRule](ctx, sdk.Client, http.MethodPut, p, nil, rule)
+	return updated, err
+}
+
+func (sdk *OneloginSDK) DeleteRiskRule(ruleID string) error {
+	return sdk.DeleteRiskRuleWithContext(context.Background(), ruleID)
+}
+
+func (sdk *OneloginSDK) DeleteRiskRuleWithContext(ctx context.Context, ruleID string) error {
+	p, err := utl.BuildAPIPath(RiskPath, "rules", ruleID)
+	if err != nil {
+		return err
+	}
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+// TrackRiskEvent reports a login or logout to Vigilance AI so it can learn the usual behavior of the user.
+func (sdk *OneloginSDK) TrackRiskEvent(event mod.RiskEvent) error {
+	return sdk.TrackRiskEventWithContext(context.Background(), event)
+}
+
+func (sdk *OneloginSDK) TrackRiskEventWithContext(ctx context.Context, event mod.RiskEvent) error {
+	p, err := utl.BuildAPIPath(RiskPath, "events")
+	if err != nil {
+		return err
+	}
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPost, p, nil, event)
+	return err
+}
+
+// GetRiskScores returns the number of scored requests per risk level, optionally restricted to a period.
+func (sdk *OneloginSDK) GetRiskScores(query *mod.RiskScoreQuery) (*mod.RiskScoreInsights, error) {
+	return sdk.GetRiskScoresWithContext(context.Background(), query)
+}
+
+func (sdk *OneloginSDK) GetRiskScoresWithContext(ctx context.Context, query *mod.RiskScoreQuery) (*mod.RiskScoreInsights, error) {
+	p, err := utl.BuildAPIPath(RiskPath, "scores")
+	if err != nil {
+		return nil, err
+	}
+	var q mod.Queryable
+	if query != nil {
+		if !utl.ValidateQueryParams(query, query.GetKeyValidators()) {
+			return nil, errors.New("invalid query parameters")
+		}
+		q = query
+	}
+	insights, _, err := api.Do[*mod.RiskScoreInsights](ctx, sdk.Client, http.MethodGet, p, q, nil)
+	return insights, err
+}
+
+// VerifyRisk scores a login attempt, from 0 (no risk) to 100, and lists the triggers behind the score.
+func (sdk *OneloginSDK) VerifyRisk(riskContext mod.RiskContext) (*mod.RiskScore, error) {
+	return sdk.VerifyRiskWithContext(context.Background(), riskContext)
+}
+
+func (sdk *OneloginSDK) VerifyRiskWithContext(ctx context.Context, riskContext mod.RiskContext) (*mod.RiskScore, error) {
+	p, err := utl.BuildAPIPath(RiskPath, "verify")
+	if err != nil {
+		return nil, err
+	}
+	score, _, err := api.Do[*mod.RiskScore](ctx, sdk.Client, http.MethodPost, p, nil, riskContext)
+	return score, err
+}
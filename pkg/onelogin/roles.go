@@ -1,6 +1,10 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -9,160 +13,176 @@ var (
 	RolePath string = "api/2/roles"
 )
 
-func (sdk *OneloginSDK) CreateRole(role *mod.Role) (interface{}, error) {
+func (sdk *OneloginSDK) CreateRole(role *mod.Role) (*mod.Role, error) {
+	return sdk.CreateRoleWithContext(context.Background(), role)
+}
+
+func (sdk *OneloginSDK) CreateRoleWithContext(ctx context.Context, role *mod.Role) (*mod.Role, error) {
 	p, err := utl.BuildAPIPath(RolePath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, role)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*mod.Role](ctx, sdk.Client, http.MethodPost, p, nil, role)
+	return created, err
 }
 
 // was ListRoles
-func (sdk *OneloginSDK) GetRoles(queryParams mod.Queryable) (interface{}, error) {
-	p := RolePath
-	resp, err := sdk.Client.Get(&p, queryParams)
+func (sdk *OneloginSDK) GetRoles(queryParams mod.Queryable) ([]mod.Role, error) {
+	return sdk.GetRolesWithContext(context.Background(), queryParams)
+}
+
+func (sdk *OneloginSDK) GetRolesWithContext(ctx context.Context, queryParams mod.Queryable) ([]mod.Role, error) {
+	p, err := utl.BuildAPIPath(RolePath)
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	roles, _, err := api.Do[[]mod.Role](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return roles, err
 }
 
-func (sdk *OneloginSDK) GetRoleByID(id int, queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetRoleByID(id int, queryParams mod.Queryable) (*mod.Role, error) {
+	return sdk.GetRoleByIDWithContext(context.Background(), id, queryParams)
+}
+
+func (sdk *OneloginSDK) GetRoleByIDWithContext(ctx context.Context, id int, queryParams mod.Queryable) (*mod.Role, error) {
 	p, err := utl.BuildAPIPath(RolePath, id)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	role, _, err := api.Do[*mod.Role](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return role, err
 }
 
-func (sdk *OneloginSDK) UpdateRole(id int, role mod.Role, queryParams map[string]string) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateRole(id int, role mod.Role, queryParams map[string]string) (*mod.Role, error) {
+	return sdk.UpdateRoleWithContext(context.Background(), id, role, queryParams)
+}
+
+func (sdk *OneloginSDK) UpdateRoleWithContext(ctx context.Context, id int, role mod.Role, queryParams map[string]string) (*mod.Role, error) {
 	p, err := utl.BuildAPIPath(RolePath, id)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, role)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*mod.Role](ctx, sdk.Client, http.MethodPut, p, nil, role)
+	return updated, err
+}
+
+func (sdk *OneloginSDK) DeleteRole(id int, queryParams map[string]string) error {
+	return sdk.DeleteRoleWithContext(context.Background(), id, queryParams)
 }
 
-func (sdk *OneloginSDK) DeleteRole(id int, queryParams map[string]string) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteRoleWithContext(ctx context.Context, id int, queryParams map[string]string) error {
 	p, err := utl.BuildAPIPath(RolePath, id)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
 
 // was ListRoleUsers
-func (sdk *OneloginSDK) GetRoleUsers(roleID int, queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetRoleUsers(roleID int, queryParams mod.Queryable) ([]mod.User, error) {
+	return sdk.GetRoleUsersWithContext(context.Background(), roleID, queryParams)
+}
+
+func (sdk *OneloginSDK) GetRoleUsersWithContext(ctx context.Context, roleID int, queryParams mod.Queryable) ([]mod.User, error) {
 	p, err := utl.BuildAPIPath(RolePath, roleID, "users")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	users, _, err := api.Do[[]mod.User](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return users, err
+}
+
+func (sdk *OneloginSDK) AddRoleUsers(roleID int) error {
+	return sdk.AddRoleUsersWithContext(context.Background(), roleID)
 }
 
-func (sdk *OneloginSDK) AddRoleUsers(roleID int) (interface{}, error) {
+func (sdk *OneloginSDK) AddRoleUsersWithContext(ctx context.Context, roleID int) error {
 	p, err := utl.BuildAPIPath(RolePath, roleID, "users")
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
 }
 
 // was removeRoleUsers
-func (sdk *OneloginSDK) DeleteRoleUsers(roleID int, users []int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteRoleUsers(roleID int, users []int) error {
+	return sdk.DeleteRoleUsersWithContext(context.Background(), roleID, users)
+}
+
+func (sdk *OneloginSDK) DeleteRoleUsersWithContext(ctx context.Context, roleID int, users []int) error {
 	p, err := utl.BuildAPIPath(RolePath, roleID, "users")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := sdk.Client.DeleteWithBody(&p, users)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, users)
+	return err
+}
+
+func (sdk *OneloginSDK) GetRoleAdmins(roleID int) ([]mod.User, error) {
+	return sdk.GetRoleAdminsWithContext(context.Background(), roleID)
 }
 
-func (sdk *OneloginSDK) GetRoleAdmins(roleID int) (interface{}, error) {
+func (sdk *OneloginSDK) GetRoleAdminsWithContext(ctx context.Context, roleID int) ([]mod.User, error) {
 	p, err := utl.BuildAPIPath(RolePath, roleID, "admins")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	users, _, err := api.Do[[]mod.User](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return users, err
+}
+
+func (sdk *OneloginSDK) AddRoleAdmins(roleID int) error {
+	return sdk.AddRoleAdminsWithContext(context.Background(), roleID)
 }
 
-func (sdk *OneloginSDK) AddRoleAdmins(roleID int) (interface{}, error) {
+func (sdk *OneloginSDK) AddRoleAdminsWithContext(ctx context.Context, roleID int) error {
 	p, err := utl.BuildAPIPath(RolePath, roleID, "admins")
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
 }
 
 // was removeRoleAdmins
-func (sdk *OneloginSDK) DeleteRoleAdmins(roleID int, admins []int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteRoleAdmins(roleID int, admins []int) error {
+	return sdk.DeleteRoleAdminsWithContext(context.Background(), roleID, admins)
+}
+
+func (sdk *OneloginSDK) DeleteRoleAdminsWithContext(ctx context.Context, roleID int, admins []int) error {
 	p, err := utl.BuildAPIPath(RolePath, roleID, "admins")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := sdk.Client.DeleteWithBody(&p, admins)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, admins)
+	return err
+}
+
+func (sdk *OneloginSDK) GetRoleApps(roleID int) ([]mod.App, error) {
+	return sdk.GetRoleAppsWithContext(context.Background(), roleID)
 }
 
-func (sdk *OneloginSDK) GetRoleApps(roleID int) (interface{}, error) {
+func (sdk *OneloginSDK) GetRoleAppsWithContext(ctx context.Context, roleID int) ([]mod.App, error) {
 	p, err := utl.BuildAPIPath(RolePath, roleID, "apps")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	apps, _, err := api.Do[[]mod.App](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return apps, err
 }
 
 // was setRoleApps
-func (sdk *OneloginSDK) UpdateRoleApps(roleID int, apps []int) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateRoleApps(roleID int, apps []int) error {
+	return sdk.UpdateRoleAppsWithContext(context.Background(), roleID, apps)
+}
+
+func (sdk *OneloginSDK) UpdateRoleAppsWithContext(ctx context.Context, roleID int, apps []int) error {
 	p, err := utl.BuildAPIPath(RolePath, roleID, "apps")
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, apps)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, apps)
+	return err
 }
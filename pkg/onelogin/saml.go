@@ -1,6 +1,10 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -9,26 +13,28 @@ const (
 	SAMLPath string = "api/2/saml_assertion"
 )
 
-func (sdk *OneloginSDK) VerifyFactorSAML(request models.VerifyMFATokenRequest) (interface{}, error) {
+func (sdk *OneloginSDK) VerifyFactorSAML(request models.VerifyMFATokenRequest) (*models.SAMLAssertion, error) {
+	return sdk.VerifyFactorSAMLWithContext(context.Background(), request)
+}
+
+func (sdk *OneloginSDK) VerifyFactorSAMLWithContext(ctx context.Context, request models.VerifyMFATokenRequest) (*models.SAMLAssertion, error) {
 	p, err := utl.BuildAPIPath(SAMLPath, "verify_factor")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, request)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	assertion, _, err := api.Do[*models.SAMLAssertion](ctx, sdk.Client, http.MethodPost, p, nil, request)
+	return assertion, err
 }
 
-func (sdk *OneloginSDK) GenerateSAMLAssertion(request models.GenerateSAMLTokenRequest) (interface{}, error) {
+func (sdk *OneloginSDK) GenerateSAMLAssertion(request models.GenerateSAMLTokenRequest) (*models.SAMLAssertion, error) {
+	return sdk.GenerateSAMLAssertionWithContext(context.Background(), request)
+}
+
+func (sdk *OneloginSDK) GenerateSAMLAssertionWithContext(ctx context.Context, request models.GenerateSAMLTokenRequest) (*models.SAMLAssertion, error) {
 	p, err := utl.BuildAPIPath(SAMLPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, request)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	assertion, _, err := api.Do[*models.SAMLAssertion](ctx, sdk.Client, http.MethodPost, p, nil, request)
+	return assertion, err
 }
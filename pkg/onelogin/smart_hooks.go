@@ -1,6 +1,10 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -9,79 +13,89 @@ const (
 	SmartHooksPath string = "api/2/hooks"
 )
 
-func (sdk *OneloginSDK) CreateHook(hook models.SmartHook) (interface{}, error) {
+func (sdk *OneloginSDK) CreateHook(hook models.SmartHook) (*models.SmartHook, error) {
+	return sdk.CreateHookWithContext(context.Background(), hook)
+}
+
+func (sdk *OneloginSDK) CreateHookWithContext(ctx context.Context, hook models.SmartHook) (*models.SmartHook, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, hook)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*models.SmartHook](ctx, sdk.Client, http.MethodPost, p, nil, hook)
+	return created, err
+}
+
+func (sdk *OneloginSDK) DeleteHook(hookID int) error {
+	return sdk.DeleteHookWithContext(context.Background(), hookID)
 }
 
-func (sdk *OneloginSDK) DeleteHook(hookID int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteHookWithContext(ctx context.Context, hookID int) error {
 	p, err := utl.BuildAPIPath(SmartHooksPath, hookID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) GetHook(hookID int, query models.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetHook(hookID int, query models.Queryable) (*models.SmartHook, error) {
+	return sdk.GetHookWithContext(context.Background(), hookID, query)
+}
+
+func (sdk *OneloginSDK) GetHookWithContext(ctx context.Context, hookID int, query models.Queryable) (*models.SmartHook, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath, hookID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, query)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	smartHook, _, err := api.Do[*models.SmartHook](ctx, sdk.Client, http.MethodGet, p, query, nil)
+	return smartHook, err
+}
+
+func (sdk *OneloginSDK) ListHooks(query models.Queryable) ([]models.SmartHook, error) {
+	return sdk.ListHooksWithContext(context.Background(), query)
 }
 
-func (sdk *OneloginSDK) ListHooks(query models.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) ListHooksWithContext(ctx context.Context, query models.Queryable) ([]models.SmartHook, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, query)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	smartHooks, _, err := api.Do[[]models.SmartHook](ctx, sdk.Client, http.MethodGet, p, query, nil)
+	return smartHooks, err
 }
 
-func (sdk *OneloginSDK) UpdateSmartHook(hookID int, hook models.SmartHook) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateSmartHook(hookID int, hook models.SmartHook) (*models.SmartHook, error) {
+	return sdk.UpdateSmartHookWithContext(context.Background(), hookID, hook)
+}
+
+func (sdk *OneloginSDK) UpdateSmartHookWithContext(ctx context.Context, hookID int, hook models.SmartHook) (*models.SmartHook, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath, hookID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, hook)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*models.SmartHook](ctx, sdk.Client, http.MethodPut, p, nil, hook)
+	return updated, err
+}
+
+func (sdk *OneloginSDK) ListEnvironmentVariables() ([]models.EnvVar, error) {
+	return sdk.ListEnvironmentVariablesWithContext(context.Background())
 }
 
-func (sdk *OneloginSDK) ListEnvironmentVariables() (interface{}, error) {
+func (sdk *OneloginSDK) ListEnvironmentVariablesWithContext(ctx context.Context) ([]models.EnvVar, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath, "envs")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	envVars, _, err := api.Do[[]models.EnvVar](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return envVars, err
+}
+
+func (sdk *OneloginSDK) CreateEnvironmentVariable(name, value string) (*models.EnvVar, error) {
+	return sdk.CreateEnvironmentVariableWithContext(context.Background(), name, value)
 }
 
-func (sdk *OneloginSDK) CreateEnvironmentVariable(name, value string) (interface{}, error) {
+func (sdk *OneloginSDK) CreateEnvironmentVariableWithContext(ctx context.Context, name, value string) (*models.EnvVar, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath, "envs")
 	if err != nil {
 		return nil, err
@@ -90,26 +104,28 @@ func (sdk *OneloginSDK) CreateEnvironmentVariable(name, value string) (interface
 		"name":  name,
 		"value": value,
 	}
-	resp, err := sdk.Client.Post(&p, envVar)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*models.EnvVar](ctx, sdk.Client, http.MethodPost, p, nil, envVar)
+	return created, err
 }
 
-func (sdk *OneloginSDK) GetEnvironmentVariable(envVarID int) (interface{}, error) {
+func (sdk *OneloginSDK) GetEnvironmentVariable(envVarID int) (*models.EnvVar, error) {
+	return sdk.GetEnvironmentVariableWithContext(context.Background(), envVarID)
+}
+
+func (sdk *OneloginSDK) GetEnvironmentVariableWithContext(ctx context.Context, envVarID int) (*models.EnvVar, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath, "envs", envVarID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	envVar, _, err := api.Do[*models.EnvVar](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return envVar, err
+}
+
+func (sdk *OneloginSDK) UpdateEnvironmentVariable(envVarID int, name, value string) (*models.EnvVar, error) {
+	return sdk.UpdateEnvironmentVariableWithContext(context.Background(), envVarID, name, value)
 }
 
-func (sdk *OneloginSDK) UpdateEnvironmentVariable(envVarID int, name, value string) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateEnvironmentVariableWithContext(ctx context.Context, envVarID int, name, value string) (*models.EnvVar, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath, "envs", envVarID)
 	if err != nil {
 		return nil, err
@@ -118,33 +134,32 @@ func (sdk *OneloginSDK) UpdateEnvironmentVariable(envVarID int, name, value stri
 		"name":  name,
 		"value": value,
 	}
-	resp, err := sdk.Client.Put(&p, envVar)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*models.EnvVar](ctx, sdk.Client, http.MethodPut, p, nil, envVar)
+	return updated, err
 }
 
-func (sdk *OneloginSDK) DeleteEnvironmentVariable(envVarID int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteEnvironmentVariable(envVarID int) error {
+	return sdk.DeleteEnvironmentVariableWithContext(context.Background(), envVarID)
+}
+
+func (sdk *OneloginSDK) DeleteEnvironmentVariableWithContext(ctx context.Context, envVarID int) error {
 	p, err := utl.BuildAPIPath(SmartHooksPath, "envs", envVarID)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+func (sdk *OneloginSDK) GetHookLogs(hookID int, query models.Queryable) ([]models.HookLog, error) {
+	return sdk.GetHookLogsWithContext(context.Background(), hookID, query)
 }
 
-func (sdk *OneloginSDK) GetHookLogs(hookID int, query models.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetHookLogsWithContext(ctx context.Context, hookID int, query models.Queryable) ([]models.HookLog, error) {
 	p, err := utl.BuildAPIPath(SmartHooksPath, hookID, "logs")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, query)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	logs, _, err := api.Do[[]models.HookLog](ctx, sdk.Client, http.MethodGet, p, query, nil)
+	return logs, err
 }
@@ -1,6 +1,10 @@
 package onelogin
 
 import (
+	"context"
+	"net/http"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -9,147 +13,160 @@ const (
 	MappingsPath string = "api/2/mappings"
 )
 
-func (sdk *OneloginSDK) ListMappings() (interface{}, error) {
+func (sdk *OneloginSDK) ListMappings() ([]mod.UserMapping, error) {
+	return sdk.ListMappingsWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) ListMappingsWithContext(ctx context.Context) ([]mod.UserMapping, error) {
 	p, err := utl.BuildAPIPath(MappingsPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	userMappings, _, err := api.Do[[]mod.UserMapping](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return userMappings, err
+}
+
+func (sdk *OneloginSDK) CreateMapping(mapping mod.UserMapping) (*mod.UserMapping, error) {
+	return sdk.CreateMappingWithContext(context.Background(), mapping)
 }
 
-func (sdk *OneloginSDK) CreateMapping(mapping mod.UserMapping) (interface{}, error) {
+func (sdk *OneloginSDK) CreateMappingWithContext(ctx context.Context, mapping mod.UserMapping) (*mod.UserMapping, error) {
 	p, err := utl.BuildAPIPath(MappingsPath)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, mapping)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*mod.UserMapping](ctx, sdk.Client, http.MethodPost, p, nil, mapping)
+	return created, err
+}
+
+func (sdk *OneloginSDK) DeleteMapping(mappingID int) error {
+	return sdk.DeleteMappingWithContext(context.Background(), mappingID)
 }
 
-func (sdk *OneloginSDK) DeleteMapping(mappingID int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteMappingWithContext(ctx context.Context, mappingID int) error {
 	p, err := utl.BuildAPIPath(MappingsPath, mappingID)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
+}
+
+func (sdk *OneloginSDK) GetMapping(mappingID int) (*mod.UserMapping, error) {
+	return sdk.GetMappingWithContext(context.Background(), mappingID)
 }
 
-func (sdk *OneloginSDK) GetMapping(mappingID int) (interface{}, error) {
+func (sdk *OneloginSDK) GetMappingWithContext(ctx context.Context, mappingID int) (*mod.UserMapping, error) {
 	p, err := utl.BuildAPIPath(MappingsPath, mappingID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	userMapping, _, err := api.Do[*mod.UserMapping](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return userMapping, err
+}
+
+func (sdk *OneloginSDK) ListActions() ([]mod.UserMappingOption, error) {
+	return sdk.ListActionsWithContext(context.Background())
 }
 
-func (sdk *OneloginSDK) ListActions() (interface{}, error) {
+func (sdk *OneloginSDK) ListActionsWithContext(ctx context.Context) ([]mod.UserMappingOption, error) {
 	p, err := utl.BuildAPIPath(MappingsPath, "actions")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	actions, _, err := api.Do[[]mod.UserMappingOption](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return actions, err
 }
 
-func (sdk *OneloginSDK) UpdateMapping(mappingID int) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateMapping(mappingID int) (*mod.UserMapping, error) {
+	return sdk.UpdateMappingWithContext(context.Background(), mappingID)
+}
+
+func (sdk *OneloginSDK) UpdateMappingWithContext(ctx context.Context, mappingID int) (*mod.UserMapping, error) {
 	p, err := utl.BuildAPIPath(MappingsPath, mappingID)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*mod.UserMapping](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return updated, err
 }
 
-func (sdk *OneloginSDK) BulkSortMappings(mappingIDs []int) (interface{}, error) {
-	p, err := utl.BuildAPIPath(MappingsPath, "bulk_sort")
-	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, mappingIDs)
+// BulkSortMappings sets the order of the mappings and returns their ids in the new order.
+func (sdk *OneloginSDK) BulkSortMappings(mappingIDs []int) ([]int, error) {
+	return sdk.BulkSortMappingsWithContext(context.Background(), mappingIDs)
+}
+
+func (sdk *OneloginSDK) BulkSortMappingsWithContext(ctx context.Context, mappingIDs []int) ([]int, error) {
+	p, err := utl.BuildAPIPath(MappingsPath, "sort")
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	sorted, _, err := api.Do[[]int](ctx, sdk.Client, http.MethodPut, p, nil, mappingIDs)
+	return sorted, err
+}
+
+func (sdk *OneloginSDK) ListActionValues(actionValue string) ([]mod.UserMappingOption, error) {
+	return sdk.ListActionValuesWithContext(context.Background(), actionValue)
 }
 
-func (sdk *OneloginSDK) ListActionValues(actionValue string) (interface{}, error) {
+func (sdk *OneloginSDK) ListActionValuesWithContext(ctx context.Context, actionValue string) ([]mod.UserMappingOption, error) {
 	p, err := utl.BuildAPIPath(MappingsPath, "actions", actionValue, "values")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	values, _, err := api.Do[[]mod.UserMappingOption](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return values, err
+}
+
+func (sdk *OneloginSDK) ListConditionValues(conditionValue string) ([]mod.UserMappingOption, error) {
+	return sdk.ListConditionValuesWithContext(context.Background(), conditionValue)
 }
 
-func (sdk *OneloginSDK) ListConditionValues(conditionValue string) (interface{}, error) {
+func (sdk *OneloginSDK) ListConditionValuesWithContext(ctx context.Context, conditionValue string) ([]mod.UserMappingOption, error) {
 	p, err := utl.BuildAPIPath(MappingsPath, "conditions", conditionValue, "values")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	values, _, err := api.Do[[]mod.UserMappingOption](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return values, err
 }
 
-func (sdk *OneloginSDK) ListConditionOperators(conditionValue string) (interface{}, error) {
+func (sdk *OneloginSDK) ListConditionOperators(conditionValue string) ([]mod.UserMappingOption, error) {
+	return sdk.ListConditionOperatorsWithContext(context.Background(), conditionValue)
+}
+
+func (sdk *OneloginSDK) ListConditionOperatorsWithContext(ctx context.Context, conditionValue string) ([]mod.UserMappingOption, error) {
 	p, err := utl.BuildAPIPath(MappingsPath, "conditions", conditionValue, "operators")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	operators, _, err := api.Do[[]mod.UserMappingOption](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return operators, err
+}
+
+func (sdk *OneloginSDK) DryrunMapping(mappingID int) ([]mod.UserMappingDryrun, error) {
+	return sdk.DryrunMappingWithContext(context.Background(), mappingID)
 }
 
-func (sdk *OneloginSDK) DryrunMapping(mappingID int) (interface{}, error) {
+func (sdk *OneloginSDK) DryrunMappingWithContext(ctx context.Context, mappingID int) ([]mod.UserMappingDryrun, error) {
 	p, err := utl.BuildAPIPath(MappingsPath, mappingID, "dryrun")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Post(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	users, _, err := api.Do[[]mod.UserMappingDryrun](ctx, sdk.Client, http.MethodPost, p, nil, nil)
+	return users, err
 }
 
 // https://<subdomain>/api/2/mappings/conditions
-func (sdk *OneloginSDK) ListConditions() (interface{}, error) {
+func (sdk *OneloginSDK) ListConditions() ([]mod.UserMappingOption, error) {
+	return sdk.ListConditionsWithContext(context.Background())
+}
+
+func (sdk *OneloginSDK) ListConditionsWithContext(ctx context.Context) ([]mod.UserMappingOption, error) {
 	p, err := utl.BuildAPIPath(MappingsPath, "conditions")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	conditions, _, err := api.Do[[]mod.UserMappingOption](ctx, sdk.Client, http.MethodGet, p, nil, nil)
+	return conditions, err
 }
@@ -1,10 +1,14 @@
 package onelogin
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
 
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
 	mod "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 	utl "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
 )
@@ -15,22 +19,25 @@ const (
 )
 
 // Users V2
-func (sdk *OneloginSDK) CreateUser(user mod.User) (interface{}, error) {
-	p, err := utl.BuildAPIPath(UserPathV2)
-	if err != nil {
-		return nil, err
-	}
+func (sdk *OneloginSDK) CreateUser(user mod.User) (*mod.User, error) {
+	return sdk.CreateUserWithContext(context.Background(), user)
+}
 
-	resp, err := sdk.Client.Post(&p, user)
+func (sdk *OneloginSDK) CreateUserWithContext(ctx context.Context, user mod.User) (*mod.User, error) {
+	p, err := utl.BuildAPIPath(UserPathV2)
 	if err != nil {
 		return nil, err
 	}
-
-	return utl.CheckHTTPResponse(resp)
+	created, _, err := api.Do[*mod.User](ctx, sdk.Client, http.MethodPost, p, nil, user)
+	return created, err
 }
 
 // was ListUsers
-func (sdk *OneloginSDK) GetUsers(query mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetUsers(query mod.Queryable) ([]mod.User, error) {
+	return sdk.GetUsersWithContext(context.Background(), query)
+}
+
+func (sdk *OneloginSDK) GetUsersWithContext(ctx context.Context, query mod.Queryable) ([]mod.User, error) {
 	p, err := utl.BuildAPIPath(UserPathV2)
 	if err != nil {
 		return nil, err
@@ -42,184 +49,217 @@ func (sdk *OneloginSDK) GetUsers(query mod.Queryable) (interface{}, error) {
 		return nil, errors.New("invalid query parameters")
 	}
 
-	resp, err := sdk.Client.Get(&p, query)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	users, _, err := api.Do[[]mod.User](ctx, sdk.Client, http.MethodGet, p, query, nil)
+	return users, err
 }
 
-func (sdk *OneloginSDK) GetUserByID(id int, queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetUserByID(id int, queryParams mod.Queryable) (*mod.User, error) {
+	return sdk.GetUserByIDWithContext(context.Background(), id, queryParams)
+}
+
+func (sdk *OneloginSDK) GetUserByIDWithContext(ctx context.Context, id int, queryParams mod.Queryable) (*mod.User, error) {
 	p, err := utl.BuildAPIPath(UserPathV2, id)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	user, _, err := api.Do[*mod.User](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return user, err
 }
 
-func (sdk *OneloginSDK) GetUserApps(id int, queryParams mod.Queryable) (interface{}, error) {
+func (sdk *OneloginSDK) GetUserApps(id int, queryParams mod.Queryable) ([]mod.UserApp, error) {
+	return sdk.GetUserAppsWithContext(context.Background(), id, queryParams)
+}
+
+func (sdk *OneloginSDK) GetUserAppsWithContext(ctx context.Context, id int, queryParams mod.Queryable) ([]mod.UserApp, error) {
 	p, err := utl.BuildAPIPath(UserPathV2, id, "apps")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, queryParams)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	userApps, _, err := api.Do[[]mod.UserApp](ctx, sdk.Client, http.MethodGet, p, queryParams, nil)
+	return userApps, err
 }
 
-func (sdk *OneloginSDK) UpdateUser(id int, user mod.User) (interface{}, error) {
+func (sdk *OneloginSDK) UpdateUser(id int, user mod.User) (*mod.User, error) {
+	return sdk.UpdateUserWithContext(context.Background(), id, user)
+}
+
+func (sdk *OneloginSDK) UpdateUserWithContext(ctx context.Context, id int, user mod.User) (*mod.User, error) {
 	p, err := utl.BuildAPIPath(UserPathV2, id)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Put(&p, user)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	updated, _, err := api.Do[*mod.User](ctx, sdk.Client, http.MethodPut, p, nil, user)
+	return updated, err
+}
+
+func (sdk *OneloginSDK) DeleteUser(id int) error {
+	return sdk.DeleteUserWithContext(context.Background(), id)
 }
 
-func (sdk *OneloginSDK) DeleteUser(id int) (interface{}, error) {
+func (sdk *OneloginSDK) DeleteUserWithContext(ctx context.Context, id int) error {
 	p, err := utl.BuildAPIPath(UserPathV2, id)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := sdk.Client.Delete(&p)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodDelete, p, nil, nil)
+	return err
 }
 
 // Users V1
-func (sdk *OneloginSDK) UpdatePasswordSecure(id int) (interface{}, error) {
+func (sdk *OneloginSDK) UpdatePasswordSecure(id int) error {
+	return sdk.UpdatePasswordSecureWithContext(context.Background(), id)
+}
+
+func (sdk *OneloginSDK) UpdatePasswordSecureWithContext(ctx context.Context, id int) error {
 	p, err := utl.BuildAPIPath(UserPathV1, "set_password_using_salt", id)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) UpdatePasswordInsecure(id int) (interface{}, error) {
+func (sdk *OneloginSDK) UpdatePasswordInsecure(id int) error {
+	return sdk.UpdatePasswordInsecureWithContext(context.Background(), id)
+}
+
+func (sdk *OneloginSDK) UpdatePasswordInsecureWithContext(ctx context.Context, id int) error {
 	p, err := utl.BuildAPIPath(UserPathV1, "set_password_clear", id)
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) LockUserAccount(id int) (interface{}, error) {
+func (sdk *OneloginSDK) LockUserAccount(id int) error {
+	return sdk.LockUserAccountWithContext(context.Background(), id)
+}
+
+func (sdk *OneloginSDK) LockUserAccountWithContext(ctx context.Context, id int) error {
 	p, err := utl.BuildAPIPath(UserPathV1, id, "lock_user")
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
 }
 
-func (sdk *OneloginSDK) GetUserRoles(id int) (interface{}, error) {
+// GetUserRoles returns the ids of the roles assigned to the user.
+func (sdk *OneloginSDK) GetUserRoles(id int) ([]int, error) {
+	return sdk.GetUserRolesWithContext(context.Background(), id)
+}
+
+func (sdk *OneloginSDK) GetUserRolesWithContext(ctx context.Context, id int) ([]int, error) {
 	p, err := utl.BuildAPIPath(UserPathV1, id, "roles")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
+	data, _, err := api.Do[json.RawMessage](ctx, sdk.Client, http.MethodGet, p, nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	return decodeV1List[int](data)
+}
+
+func (sdk *OneloginSDK) LogOutUser(userID int) error {
+	return sdk.LogOutUserWithContext(context.Background(), userID)
 }
 
-func (sdk *OneloginSDK) LogOutUser(userID int) (interface{}, error) {
+func (sdk *OneloginSDK) LogOutUserWithContext(ctx context.Context, userID int) error {
 	p, err := utl.BuildAPIPath(UserPathV1, userID, "logout")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
+}
+
+func (sdk *OneloginSDK) AssignRolesToUser(userID int, roles []int) error {
+	return sdk.AssignRolesToUserWithContext(context.Background(), userID, roles)
 }
 
-func (sdk *OneloginSDK) AssignRolesToUser(userID int, roles []int) (interface{}, error) {
+func (sdk *OneloginSDK) AssignRolesToUserWithContext(ctx context.Context, userID int, roles []int) error {
 	p, err := utl.BuildAPIPath(UserPathV1, userID, "add_roles")
 	if err != nil {
-		return nil, err
+		return err
 	}
 	payload := map[string][]int{"role_id_array": roles}
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, payload)
+	return err
 }
 
-func (sdk *OneloginSDK) SetUserState(userID, state int) (interface{}, error) {
+func (sdk *OneloginSDK) SetUserState(userID, state int) error {
+	return sdk.SetUserStateWithContext(context.Background(), userID, state)
+}
+
+func (sdk *OneloginSDK) SetUserStateWithContext(ctx context.Context, userID, state int) error {
 	p, err := utl.BuildAPIPath(UserPathV1, userID, "set_state")
 	if err != nil {
-		return nil, err
+		return err
 	}
 	payload := map[string]int{"state": state}
-	resp, err := sdk.Client.Put(&p, payload)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, payload)
+	return err
 }
 
-func (sdk *OneloginSDK) RemoveUserRole(userID int) (interface{}, error) {
+func (sdk *OneloginSDK) RemoveUserRole(userID int) error {
+	return sdk.RemoveUserRoleWithContext(context.Background(), userID)
+}
+
+func (sdk *OneloginSDK) RemoveUserRoleWithContext(ctx context.Context, userID int) error {
 	p, err := utl.BuildAPIPath(UserPathV1, userID, "remove_roles")
 	if err != nil {
-		return nil, err
-	}
-	resp, err := sdk.Client.Put(&p, nil)
-	if err != nil {
-		return nil, err
+		return err
 	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, nil)
+	return err
+}
+
+// GetCustomAttributes returns the short names of the custom user attributes of the account.
+func (sdk *OneloginSDK) GetCustomAttributes() ([]string, error) {
+	return sdk.GetCustomAttributesWithContext(context.Background())
 }
 
-func (sdk *OneloginSDK) GetCustomAttributes() (interface{}, error) {
+func (sdk *OneloginSDK) GetCustomAttributesWithContext(ctx context.Context) ([]string, error) {
 	p, err := utl.BuildAPIPath(UserPathV1, "custom_attributes")
 	if err != nil {
 		return nil, err
 	}
-	resp, err := sdk.Client.Get(&p, nil)
+	data, _, err := api.Do[json.RawMessage](ctx, sdk.Client, http.MethodGet, p, nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	return utl.CheckHTTPResponse(resp)
+	return decodeV1List[string](data)
 }
 
-func (sdk *OneloginSDK) SetCustomAttributes(userID int, attr interface{}) (interface{}, error) {
+func (sdk *OneloginSDK) SetCustomAttributes(userID int, attr interface{}) error {
+	return sdk.SetCustomAttributesWithContext(context.Background(), userID, attr)
+}
+
+func (sdk *OneloginSDK) SetCustomAttributesWithContext(ctx context.Context, userID int, attr interface{}) error {
 	p, err := utl.BuildAPIPath(UserPathV1, userID, "set_custom_attributes")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resp, err := sdk.Client.Put(&p, attr)
-	if err != nil {
-		return nil, err
-	}
-	return utl.CheckHTTPResponse(resp)
+	_, _, err = api.Do[interface{}](ctx, sdk.Client, http.MethodPut, p, nil, attr)
+	return err
+}
+
+// decodeV1List decodes a list returned by API v1, which wraps some lists in a single element list,
+// e.g. "data": [[1, 2]].
+func decodeV1List[T any](data json.RawMessage) ([]T, error) {
+	var nested [][]T
+	if err := json.Unmarshal(data, &nested); err == nil {
+		var list []T
+		for _, l := range nested {
+			list = append(list, l...)
+		}
+		return list, nil
+	}
+	var list []T
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, olerror.WrapSerializationError(fmt.Sprintf("failed to unmarshal response body into %T", list), err)
+	}
+	return list, nil
 }
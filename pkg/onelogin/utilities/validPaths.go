@@ -50,6 +50,7 @@ var validPaths = []string{
 	"^/api/2/saml_assertion/verify_factor$",
 	"^/api/2/mappings$",
 	"^/api/2/mappings/[0-9]+$",
+	"^/api/2/mappings/[0-9]+/dryrun$",
 	"^/api/2/mappings/conditions$",
 	"^/api/2/mappings/conditions/[a-zA-Z0-9]+/operators$",
 	"^/api/2/mappings/conditions/[a-zA-Z0-9]+/values$",
@@ -70,7 +71,7 @@ var validPaths = []string{
 	"^/api/2/apps/[0-9]+/rules/sort$",
 	"^/api/2/connectors$",
 	"^/api/2/risk/rules$",
-	"^/api/2/risk/rules/[0-9]+$",
+	"^/api/2/risk/rules/[a-zA-Z0-9-]+$",
 	"^/api/2/risk/events$",
 	"^/api/2/risk/scores$",
 	"^/api/2/risk/verify$",
@@ -102,6 +103,6 @@ var validPaths = []string{
 	"^/api/2/branding/brands/[0-9]+/apps$",
 	"^/api/2/branding/email_settings$",
 	"^/api/2/branding/email_settings/test$",
-	"^/api/2/branding/brands/[0-9]+/templates/[a-zA-Z]+/[a-zA-Z]+$",
-	"^/api/2/branding/brands/master/templates/[a-zA-Z]+$",
+	"^/api/2/branding/brands/[0-9]+/templates/[a-zA-Z_]+/[a-zA-Z-]+$",
+	"^/api/2/branding/brands/master/templates/[a-zA-Z_]+$",
 }
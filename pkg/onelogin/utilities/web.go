@@ -6,17 +6,18 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
 )
 
 // receive http response, check error code status, if good return json of resp.Body
-// else return error
+// else return an *olerror.APIError describing the failure
 func CheckHTTPResponse(resp *http.Response) (interface{}, error) {
 	// Check if the request was successful
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("request failed with status: %d", resp.StatusCode)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, olerror.NewAPIErrorFromResponse(resp)
 	}
 
 	// Read the response body
@@ -32,19 +33,31 @@ func CheckHTTPResponse(resp *http.Response) (interface{}, error) {
 	}
 
 	// Try to unmarshal the response body into a map[string]interface{} or []interface{}
+	data, err := unmarshalBody(body)
+	if err != nil {
+		return nil, err
+	}
+
+	//log.Printf("Response body unmarshaled successfully: %v\n", data)
+	return data, nil
+}
+
+// unmarshalBody unmarshals a JSON object or array into a map[string]interface{} or []interface{}.
+// Other bodies are returned as a string.
+func unmarshalBody(body []byte) (interface{}, error) {
 	var data interface{}
 	bodyStr := string(body)
 	//log.Printf("Response body: %s\n", bodyStr)
 	if strings.HasPrefix(bodyStr, "[") {
 		var slice []interface{}
-		err = json.Unmarshal(body, &slice)
+		err := json.Unmarshal(body, &slice)
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal response body into []interface{}: %w", err)
 		}
 		data = slice
 	} else if strings.HasPrefix(bodyStr, "{") {
 		var dict map[string]interface{}
-		err = json.Unmarshal(body, &dict)
+		err := json.Unmarshal(body, &dict)
 		if err != nil {
 			return nil, fmt.Errorf("failed to unmarshal response body into map[string]interface{}: %w", err)
 		}
@@ -53,17 +66,78 @@ func CheckHTTPResponse(resp *http.Response) (interface{}, error) {
 		data = bodyStr
 	}
 
-	//log.Printf("Response body unmarshaled successfully: %v\n", data)
 	return data, nil
 }
 
+// Pagination holds the cursors API v1 endpoints return in the body envelope.
+type Pagination struct {
+	BeforeCursor string `json:"before_cursor"`
+	AfterCursor  string `json:"after_cursor"`
+	PreviousLink string `json:"previous_link"`
+	NextLink     string `json:"next_link"`
+}
+
+// DecodeHTTPResponse checks the response status and unmarshals the JSON body into v.
+// A non-2xx status is returned as an *olerror.APIError.
+// Bodies wrapped in the API v1 {"status": ..., "data": ...} envelope are unwrapped first.
+// An empty body leaves v untouched.
+func DecodeHTTPResponse(resp *http.Response, v interface{}) error {
+	_, err := DecodePaginatedHTTPResponse(resp, v)
+	return err
+}
+
+// DecodePaginatedHTTPResponse is like DecodeHTTPResponse and also returns the pagination
+// of an API v1 envelope, or nil when the body carries none.
+func DecodePaginatedHTTPResponse(resp *http.Response, v interface{}) (*Pagination, error) {
+	// Check if the request was successful
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, olerror.NewAPIErrorFromResponse(resp)
+	}
+
+	// Read and close the response body
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if v == nil || len(strings.TrimSpace(string(body))) == 0 {
+		return nil, nil
+	}
+	if raw, ok := v.(*interface{}); ok {
+		// Untyped targets get the same maps, slices or strings as CheckHTTPResponse.
+		data, err := unmarshalBody(body)
+		if err != nil {
+			return nil, err
+		}
+		*raw = data
+		return nil, nil
+	}
+
+	var envelope struct {
+		Status     json.RawMessage `json:"status"`
+		Data       json.RawMessage `json:"data"`
+		Pagination *Pagination     `json:"pagination"`
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(body)), "{") &&
+		json.Unmarshal(body, &envelope) == nil && envelope.Status != nil && envelope.Data != nil {
+		body = envelope.Data
+	} else {
+		envelope.Pagination = nil
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, olerror.WrapSerializationError(fmt.Sprintf("failed to unmarshal response body into %T", v), err)
+	}
+	return envelope.Pagination, nil
+}
+
 func BuildAPIPath(parts ...interface{}) (string, error) {
 	var path string
 	for _, part := range parts {
 		switch p := part.(type) {
 		case string:
 			path += "/" + p
-		case int:
+		case int, int64:
 			path += fmt.Sprintf("/%d", p)
 		default:
 			// Handle other types if needed
@@ -99,18 +173,38 @@ func AddQueryToPath(path string, query interface{}) (string, error) {
 	return path, nil
 }
 
+// queryToValues converts the JSON fields of a query model into URL values.
 func queryToValues(query interface{}) (url.Values, error) {
 	values := url.Values{}
+	if query == nil {
+		return values, nil
+	}
 
-	// Convert query parameters to URL-encoded string
-	if query != nil {
-		queryBytes, err := json.Marshal(query)
-		if err != nil {
-			return nil, err
-		}
-		err = json.Unmarshal(queryBytes, &values)
-		if err != nil {
-			return nil, err
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(queryBytes, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			values.Set(key, v)
+		case float64:
+			values.Set(key, strconv.FormatFloat(v, 'f', -1, 64))
+		case bool:
+			values.Set(key, strconv.FormatBool(v))
+		default:
+			// Nested values are passed as JSON.
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			values.Set(key, string(b))
 		}
 	}
 
@@ -2,12 +2,17 @@ package tests
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
 )
 
 type MockHttpClient struct {
@@ -31,6 +36,14 @@ func (m *MockAuthenticator) NewAuthenticator() *authentication.Authenticator {
 	return &authentication.Authenticator{}
 }
 
+func (m *MockAuthenticator) Token() (*authentication.Token, error) {
+	tk, err := m.GetTokenFunc()
+	if err != nil {
+		return nil, err
+	}
+	return &authentication.Token{AccessToken: tk}, nil
+}
+
 func createMockClient() *api.Client {
 	mockClient := &MockHttpClient{}
 	mockAuth := &MockAuthenticator{}
@@ -39,10 +52,9 @@ func createMockClient() *api.Client {
 		return "mockToken", nil
 	}
 
-	auth := authentication.NewAuthenticator("test")
 	client := &api.Client{
 		HttpClient: mockClient,
-		Auth:       auth,
+		Auth:       mockAuth,
 		OLdomain:   "https://api.onelogin.com",
 	}
 
@@ -142,3 +154,285 @@ func TestClientDeleteWithBody(t *testing.T) {
 		t.Fatalf("Expected ``, got %s", string(body))
 	}
 }
+
+func TestClientGetWithContext(t *testing.T) {
+	client := createMockClient()
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.Context().Value(ctxKey{}) != "value" {
+			t.Fatalf("Expected request to carry the caller's context")
+		}
+		if err := req.Context().Err(); err != nil {
+			return nil, err
+		}
+		response := &http.Response{
+			StatusCode: 200,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"key":"value"}`)),
+		}
+		return response, nil
+	}
+
+	resp, err := client.GetWithContext(ctx, new(string), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := client.GetWithContext(canceled, new(string), nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestClientRetryRewindsBody(t *testing.T) {
+	client := createMockClient()
+	client.Retry = &api.RetryPolicy{
+		MaxAttempts:       3,
+		BaseDelay:         time.Millisecond,
+		MaxDelay:          5 * time.Millisecond,
+		RetryableStatuses: map[int]bool{http.StatusServiceUnavailable: true},
+	}
+
+	attempts := 0
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		attempts++
+		body, _ := ioutil.ReadAll(req.Body)
+		if string(body) != `{"foo":"bar"}` {
+			t.Fatalf("Attempt %d: expected `{\"foo\":\"bar\"}`, got %s", attempts, string(body))
+		}
+		status := http.StatusServiceUnavailable
+		if attempts == 3 {
+			status = http.StatusCreated
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"result":"created"}`)),
+		}, nil
+	}
+
+	resp, err := client.Put(new(string), map[string]string{"foo": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated || attempts != 3 {
+		t.Fatalf("Expected 201 after 3 attempts, got %d after %d", resp.StatusCode, attempts)
+	}
+}
+
+func TestClientRetryNonIdempotent(t *testing.T) {
+	client := createMockClient()
+	client.Retry = &api.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		RetryableStatuses: map[int]bool{
+			http.StatusTooManyRequests:    true,
+			http.StatusServiceUnavailable: true,
+		},
+		RespectRetryAfter: true,
+	}
+
+	var statuses []int
+	attempts := 0
+	client.HttpClient.(*MockHttpClient).DoFunc = func(*http.Request) (*http.Response, error) {
+		status, header := http.StatusCreated, http.Header{}
+		if attempts < len(statuses) {
+			status = statuses[attempts]
+			header.Set("Retry-After", "0")
+		}
+		attempts++
+		if status == -1 {
+			return nil, syscall.ECONNRESET
+		}
+		return &http.Response{StatusCode: status, Header: header, Body: ioutil.NopCloser(bytes.NewBufferString(``))}, nil
+	}
+
+	// A POST may have been applied before a 503 or a connection reset, so it is not retried.
+	for _, status := range []int{http.StatusServiceUnavailable, -1} {
+		statuses, attempts = []int{status}, 0
+		resp, _ := client.Post(new(string), map[string]string{"foo": "bar"})
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if attempts != 1 {
+			t.Fatalf("Expected no retry of a POST after %d, got %d attempts", status, attempts)
+		}
+	}
+
+	// A 429 with Retry-After was rejected before processing.
+	statuses, attempts = []int{http.StatusTooManyRequests}, 0
+	resp, err := client.Post(new(string), map[string]string{"foo": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || attempts != 2 {
+		t.Fatalf("Expected 201 after 2 attempts, got %d after %d", resp.StatusCode, attempts)
+	}
+
+	client.Retry.RetryNonIdempotent = true
+	statuses, attempts = []int{http.StatusServiceUnavailable, -1}, 0
+	resp, err = client.Post(new(string), map[string]string{"foo": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || attempts != 3 {
+		t.Fatalf("Expected 201 after 3 attempts with RetryNonIdempotent, got %d after %d", resp.StatusCode, attempts)
+	}
+}
+
+func TestClientRetryGivesUp(t *testing.T) {
+	client := createMockClient()
+	client.Retry = &api.RetryPolicy{
+		MaxAttempts:       2,
+		BaseDelay:         time.Millisecond,
+		RetryableStatuses: map[int]bool{http.StatusTooManyRequests: true},
+		RespectRetryAfter: true,
+	}
+
+	attempts := 0
+	client.HttpClient.(*MockHttpClient).DoFunc = func(*http.Request) (*http.Response, error) {
+		attempts++
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"0"}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(``)),
+		}, nil
+	}
+
+	resp, err := client.Get(new(string), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests || attempts != 2 {
+		t.Fatalf("Expected 429 after 2 attempts, got %d after %d", resp.StatusCode, attempts)
+	}
+}
+
+func TestClientRateLimitTracking(t *testing.T) {
+	client := createMockClient()
+	client.Throttle = true
+
+	calls := 0
+	client.HttpClient.(*MockHttpClient).DoFunc = func(*http.Request) (*http.Response, error) {
+		calls++
+		header := http.Header{}
+		header.Set(api.RateLimitLimitHeader, "5000")
+		header.Set(api.RateLimitRemainingHeader, "0")
+		header.Set(api.RateLimitResetHeader, "60")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{}`)),
+		}, nil
+	}
+
+	if _, ok := client.RateLimit(); ok {
+		t.Fatalf("Expected no rate limit before the first response")
+	}
+
+	resp, err := client.Get(new(string), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	rl, ok := client.RateLimit()
+	if !ok || rl.Limit != 5000 || rl.Remaining != 0 || time.Until(rl.Reset) <= 0 {
+		t.Fatalf("Unexpected rate limit: %+v", rl)
+	}
+
+	// The budget is exhausted, so a throttled client must wait for the reset instead of sending.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetWithContext(ctx, new(string), nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("Expected the throttled request not to be sent, got %d calls", calls)
+	}
+}
+
+func TestDoDecodesTypedResponse(t *testing.T) {
+	client := createMockClient()
+
+	var sent *http.Request
+	var sentBody []byte
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		sent = req
+		sentBody, _ = ioutil.ReadAll(req.Body)
+		header := http.Header{}
+		header.Set(api.RequestIDHeader, "req-123")
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":5,"name":"Admins"}`)),
+		}, nil
+	}
+
+	name := "Admins"
+	role, resp, err := api.Do[*models.Role](context.Background(), client, http.MethodPost, "/api/2/roles", nil, models.Role{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role == nil || *role.ID != 5 || *role.Name != "Admins" {
+		t.Fatalf("Unexpected role: %+v", role)
+	}
+	if resp.StatusCode != http.StatusCreated || resp.RequestID != "req-123" {
+		t.Fatalf("Unexpected response metadata: %+v", resp)
+	}
+	if sent.Method != http.MethodPost || string(sentBody) != `{"name":"Admins"}` {
+		t.Fatalf("Unexpected request: %s %s", sent.Method, sentBody)
+	}
+}
+
+func TestDoReportsFailureStatus(t *testing.T) {
+	client := createMockClient()
+	client.HttpClient.(*MockHttpClient).DoFunc = func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"message":"Not Found"}`)),
+		}, nil
+	}
+
+	users, resp, err := api.Do[[]models.User](context.Background(), client, http.MethodGet, "/api/2/users", nil, nil)
+	if err == nil || users != nil {
+		t.Fatalf("Expected an error and no users, got %v and %v", users, err)
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected response metadata for the failed request, got %+v", resp)
+	}
+}
+
+func TestDoUntypedResponse(t *testing.T) {
+	client := createMockClient()
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.Body != http.NoBody {
+			t.Errorf("Expected no request body")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"status":{"code":200},"data":[1,2]}`)),
+		}, nil
+	}
+
+	result, _, err := api.Do[interface{}](context.Background(), client, http.MethodGet, "/api/1/users/1/roles", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, ok := result.(map[string]interface{})
+	if !ok || body["data"] == nil {
+		t.Fatalf("Expected the raw response body, got %#v", result)
+	}
+}
@@ -0,0 +1,284 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+)
+
+// newTokenServer returns a token endpoint issuing sequential tokens that expire after expiresIn seconds.
+func newTokenServer(t *testing.T, expiresIn int, delay time.Duration) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != authentication.TkPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n := atomic.AddInt32(&calls, 1)
+		time.Sleep(delay)
+		fmt.Fprintf(w, `{"access_token":"token-%d","refresh_token":"refresh-%d","token_type":"bearer","account_id":7,"created_at":"2023-06-01T10:00:00Z","expires_in":%d}`, n, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newTestAuthenticator(baseURL string) *authentication.Authenticator {
+	return authentication.NewAuthenticatorWithConfig(authentication.Config{
+		BaseURL:      baseURL,
+		ClientID:     "id",
+		ClientSecret: "secret",
+		RefreshSkew:  time.Minute,
+	})
+}
+
+func TestAuthenticatorTokenMetadata(t *testing.T) {
+	server, _ := newTokenServer(t, 36000, 0)
+	auth := newTestAuthenticator(server.URL)
+
+	tk, err := auth.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.AccessToken != "token-1" || tk.RefreshToken != "refresh-1" || tk.TokenType != "bearer" || tk.AccountID != 7 {
+		t.Fatalf("Unexpected token metadata: %+v", tk)
+	}
+	if tk.ExpiresIn != 36000 || time.Until(tk.Expiry) < 9*time.Hour || tk.CreatedAt.IsZero() {
+		t.Fatalf("Unexpected token lifetime: %+v", tk)
+	}
+	if !tk.Valid() {
+		t.Fatalf("Expected token to be valid")
+	}
+}
+
+func TestAuthenticatorProactiveRefresh(t *testing.T) {
+	// Tokens expire within the one minute refresh skew, so every read refreshes.
+	server, calls := newTokenServer(t, 30, 0)
+	auth := newTestAuthenticator(server.URL)
+
+	if err := auth.GenerateToken(); err != nil {
+		t.Fatal(err)
+	}
+	tk, err := auth.GetToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk != "token-2" || atomic.LoadInt32(calls) != 2 {
+		t.Fatalf("Expected a proactive refresh, got %q after %d calls", tk, atomic.LoadInt32(calls))
+	}
+}
+
+func TestAuthenticatorSingleFlightRefresh(t *testing.T) {
+	server, calls := newTokenServer(t, 36000, 50*time.Millisecond)
+	auth := newTestAuthenticator(server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := auth.GenerateToken(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(calls); n != 1 {
+		t.Fatalf("Expected concurrent refreshes to share one request, got %d", n)
+	}
+}
+
+func TestAuthenticatorRefreshOutlivesCanceledCaller(t *testing.T) {
+	server, calls := newTokenServer(t, 36000, 50*time.Millisecond)
+	auth := newTestAuthenticator(server.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() { leader <- auth.GenerateTokenWithContext(ctx) }()
+	time.Sleep(10 * time.Millisecond)
+	waiter := make(chan error)
+	go func() { waiter <- auth.GenerateTokenWithContext(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the canceled caller to get context.Canceled, got %v", err)
+	}
+	if err := <-waiter; err != nil {
+		t.Fatalf("Expected the waiting caller to get the token, got %v", err)
+	}
+	tk, err := auth.GetToken()
+	if err != nil || tk != "token-1" || atomic.LoadInt32(calls) != 1 {
+		t.Fatalf("Expected token-1 from one request, got %q, %v after %d calls", tk, err, atomic.LoadInt32(calls))
+	}
+}
+
+func TestStaticTokenSource(t *testing.T) {
+	client := createMockClient()
+	client.Auth = authentication.StaticTokenSource("static-token")
+
+	calls := 0
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		calls++
+		if req.Header.Get("Authorization") != "Bearer static-token" {
+			t.Fatalf("Expected static token, got %q", req.Header.Get("Authorization"))
+		}
+		return &http.Response{
+			StatusCode: http.StatusUnauthorized,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(``)),
+		}, nil
+	}
+
+	resp, err := client.Get(new(string), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// A static token cannot be renewed, so the 401 is returned as is.
+	if resp.StatusCode != http.StatusUnauthorized || calls != 1 {
+		t.Fatalf("Expected a single 401 response, got %d after %d calls", resp.StatusCode, calls)
+	}
+}
+
+func TestDefaultTokenSourceFallsBackToCredentialsFile(t *testing.T) {
+	server, _ := newTokenServer(t, 36000, 0)
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	content := "# test credentials\n[default]\nclient_id = file-id\nclient_secret = \"file-secret\"\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(authentication.EnvCredentialsFile, path)
+	t.Setenv("ONELOGIN_CLIENT_ID", "")
+	t.Setenv("ONELOGIN_CLIENT_SECRET", "")
+
+	src := authentication.DefaultTokenSource(authentication.Config{BaseURL: server.URL})
+	tk, err := src.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.AccessToken != "token-1" {
+		t.Fatalf("Expected token from the credentials file source, got %q", tk.AccessToken)
+	}
+}
+
+func TestChainTokenSourceReportsLastError(t *testing.T) {
+	src := authentication.ChainTokenSource(
+		authentication.FileTokenSource(filepath.Join(t.TempDir(), "missing"), authentication.Config{}),
+	)
+	if _, err := src.Token(); err == nil {
+		t.Fatalf("Expected an error when no source yields a token")
+	}
+}
+
+func TestDefaultTokenSourceReportsRejectedCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	t.Setenv(authentication.EnvCredentialsFile, filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("ONELOGIN_CLIENT_ID", "")
+	t.Setenv("ONELOGIN_CLIENT_SECRET", "")
+
+	src := authentication.DefaultTokenSource(authentication.Config{BaseURL: server.URL, ClientID: "id", ClientSecret: "bad"})
+	_, err := src.Token()
+	if !olerror.IsUnauthorized(err) {
+		t.Fatalf("Expected the rejected credentials to be reported, got %v", err)
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected the missing credentials file not to hide the 401, got %v", err)
+	}
+}
+
+func TestChainTokenSourceStopsAtFailingSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	t.Setenv("ONELOGIN_CLIENT_ID", "env-id")
+	t.Setenv("ONELOGIN_CLIENT_SECRET", "env-secret")
+
+	var envCalls int32
+	envServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&envCalls, 1)
+		fmt.Fprint(w, `{"access_token":"env-token","expires_in":36000}`)
+	}))
+	defer envServer.Close()
+
+	src := authentication.ChainTokenSource(
+		authentication.NewAuthenticatorWithConfig(authentication.Config{BaseURL: server.URL, ClientID: "id", ClientSecret: "bad"}),
+		authentication.EnvTokenSource(authentication.Config{BaseURL: envServer.URL}),
+	)
+	if _, err := src.Token(); !olerror.IsUnauthorized(err) {
+		t.Fatalf("Expected the first source's 401, got %v", err)
+	}
+	if envCalls != 0 {
+		t.Fatalf("Expected the chain not to fall through to the environment, got %d token requests", envCalls)
+	}
+}
+
+func TestSDKCloseRevokesToken(t *testing.T) {
+	var tokenCalls int32
+	var revoked []string
+	revokeStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case authentication.TkPath:
+			n := atomic.AddInt32(&tokenCalls, 1)
+			fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":36000}`, n)
+		case authentication.RevokePath:
+			var body struct {
+				AccessToken string `json:"access_token"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			revoked = append(revoked, body.AccessToken)
+			w.WriteHeader(revokeStatus)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	sdk, err := onelogin.NewOneloginSDK(api.WithBaseURL(server.URL), api.WithCredentials("id", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdk.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(revoked) != 1 || revoked[0] != "token-1" {
+		t.Fatalf("Expected token-1 to be revoked, got %v", revoked)
+	}
+
+	// The cached token is gone, so the next use generates a new one.
+	tk, err := sdk.GetToken()
+	if err != nil || tk != "token-2" {
+		t.Fatalf("Expected a new token after Close, got %q (%v)", tk, err)
+	}
+
+	revokeStatus = http.StatusBadRequest
+	err = sdk.Close(context.Background())
+	var authErr *olerror.AuthenticationError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("Expected an AuthenticationError, got %T: %v", err, err)
+	}
+}
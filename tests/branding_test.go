@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+func TestBrandCRUD(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"id":9,"name":"Acme","enabled":true,"custom_color":"#123456","logo":{"original_url":"https://example.com/logo.png","file_size":1024,"content_type":"image/png"}}`, &requests)
+
+	name, enabled := "Acme", true
+	brand, err := sdk.CreateBrand(models.Brand{Name: &name, Enabled: &enabled})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *brand.ID != 9 || *brand.CustomColor != "#123456" || brand.Logo.FileSize != 1024 {
+		t.Fatalf("Unexpected brand: %+v", brand)
+	}
+	if _, err := sdk.UpdateBrand(9, *brand); err != nil {
+		t.Fatal(err)
+	}
+	if err := sdk.DeleteBrand(9); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct{ method, path string }{
+		{http.MethodPost, "/api/2/branding/brands"},
+		{http.MethodPut, "/api/2/branding/brands/9"},
+		{http.MethodDelete, "/api/2/branding/brands/9"},
+	}
+	for i, e := range expected {
+		if requests[i].Method != e.method || requests[i].URL.Path != e.path {
+			t.Fatalf("Expected %s %s, got %s %s", e.method, e.path, requests[i].Method, requests[i].URL.Path)
+		}
+	}
+}
+
+func TestAssignAppsToBrand(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{}`, &requests)
+
+	if err := sdk.AssignAppsToBrand(9, []int{101, 102}); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(requests[0].Body)
+	if requests[0].Method != http.MethodPut || requests[0].URL.Path != "/api/2/branding/brands/9/apps" || string(body) != "[101,102]" {
+		t.Fatalf("Unexpected request %s %s with body %s", requests[0].Method, requests[0].URL, body)
+	}
+}
+
+func TestGetMessageTemplateByType(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"id":3,"type":"email_forgot_password","locale":"pt-BR","template":{"subject":"Redefinir senha","html":"<p>Oi</p>","plain":"Oi"}}`, &requests)
+
+	template, err := sdk.GetMessageTemplateByType(9, models.TemplateEmailForgotPassword, "pt-BR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *template.ID != 3 || *template.Template.Subject != "Redefinir senha" {
+		t.Fatalf("Unexpected template: %+v", template)
+	}
+	if requests[0].URL.Path != "/api/2/branding/brands/9/templates/email_forgot_password/pt-BR" {
+		t.Fatalf("Unexpected request %s", requests[0].URL)
+	}
+
+	if _, err := sdk.GetMasterTemplate(models.TemplateSMSOTP); err != nil {
+		t.Fatal(err)
+	}
+	if requests[1].URL.Path != "/api/2/branding/brands/master/templates/sms_otp" {
+		t.Fatalf("Unexpected request %s", requests[1].URL)
+	}
+
+	if _, err := sdk.GetMessageTemplateByType(9, "../users", "en"); err == nil {
+		t.Fatal("Expected an invalid template type to be rejected")
+	}
+}
+
+func TestEmailSettings(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"address":"smtp.example.com","use_tls":true,"from":"it@example.com","port":587}`, &requests)
+
+	settings, err := sdk.GetEmailSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *settings.Address != "smtp.example.com" || *settings.Port != 587 || !*settings.UseTLS {
+		t.Fatalf("Unexpected settings: %+v", settings)
+	}
+
+	if err := sdk.SendTestEmail("jane@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(requests[1].Body)
+	var sent models.TestEmailRequest
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if requests[1].Method != http.MethodPost || requests[1].URL.Path != "/api/2/branding/email_settings/test" || sent.Email != "jane@example.com" {
+		t.Fatalf("Unexpected request %s %s with body %s", requests[1].Method, requests[1].URL, body)
+	}
+}
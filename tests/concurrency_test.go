@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
+)
+
+// Run with -race: parallel requests share one SDK while the server revokes the first token.
+func TestConcurrentRequestsAcrossTokenRefresh(t *testing.T) {
+	var tokenCalls, apiCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case authentication.TkPath:
+			n := atomic.AddInt32(&tokenCalls, 1)
+			fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":36000}`, n)
+		case "/api/2/users":
+			atomic.AddInt32(&apiCalls, 1)
+			if r.Header.Get("Authorization") != "Bearer token-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	sdk, err := onelogin.NewOneloginSDK(
+		api.WithBaseURL(server.URL),
+		api.WithCredentials("id", "secret"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			path := "/api/2/users"
+			resp, err := sdk.Client.Get(&path, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("Expected 200 after refresh, got %d", resp.StatusCode)
+			}
+			if _, err := sdk.GetToken(); err != nil {
+				t.Error(err)
+			}
+			sdk.RateLimit()
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&tokenCalls); n != 2 {
+		t.Fatalf("Expected the initial token and a single shared refresh, got %d token requests", n)
+	}
+	if n := atomic.LoadInt32(&apiCalls); n > 2*workers {
+		t.Fatalf("Expected at most one retry per request, got %d API calls", n)
+	}
+}
@@ -0,0 +1,280 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/onelogintest"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(api.EnvSubdomain, "env-tenant")
+	t.Setenv(api.EnvClientID, "env-id")
+	t.Setenv(api.EnvClientSecret, "env-secret")
+	t.Setenv(api.EnvTimeout, "15")
+
+	cfg := api.ConfigFromEnv()
+	if cfg.Subdomain != "env-tenant" || cfg.ClientID != "env-id" || cfg.ClientSecret != "env-secret" {
+		t.Fatalf("Unexpected config from env: %+v", cfg)
+	}
+	if cfg.Timeout != 15*time.Second {
+		t.Fatalf("Expected 15s timeout, got %s", cfg.Timeout)
+	}
+
+	// Explicit options placed after FromEnv take precedence.
+	cfg = api.NewConfig(api.FromEnv(), api.WithSubdomain("explicit"))
+	if cfg.Subdomain != "explicit" || cfg.ClientID != "env-id" {
+		t.Fatalf("Unexpected merged config: %+v", cfg)
+	}
+}
+
+func TestNewOneloginSDKWithOptions(t *testing.T) {
+	t.Setenv(api.EnvClientID, "env-id")
+	t.Setenv(api.EnvClientSecret, "env-secret")
+
+	mockClient := &MockHttpClient{}
+	mockClient.DoFunc = func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() != "https://tenant-a.onelogin.com/auth/oauth2/v2/token" {
+			t.Fatalf("Unexpected token URL %s", req.URL)
+		}
+		expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("id-a:secret-a"))
+		if req.Header.Get("Authorization") != expected {
+			t.Fatalf("Expected explicit credentials, got %q", req.Header.Get("Authorization"))
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"access_token":"token-a"}`)),
+		}, nil
+	}
+
+	sdk, err := onelogin.NewOneloginSDK(
+		api.WithSubdomain("tenant-a"),
+		api.WithCredentials("id-a", "secret-a"),
+		api.WithHTTPClient(mockClient),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk, err := sdk.GetToken()
+	if err != nil || tk != "token-a" {
+		t.Fatalf("Expected token-a, got %q (%v)", tk, err)
+	}
+}
+
+func TestNewOneloginSDKMissingCredentials(t *testing.T) {
+	_, err := onelogin.NewOneloginSDK(api.WithSubdomain("tenant-a"))
+	if err == nil || !strings.Contains(err.Error(), "client ID") {
+		t.Fatalf("Expected missing client ID error, got %v", err)
+	}
+}
+
+func TestConfigResolveBaseURL(t *testing.T) {
+	cases := []struct {
+		cfg      api.Config
+		expected string
+	}{
+		{api.Config{Subdomain: "acme"}, "https://acme.onelogin.com"},
+		{api.Config{Subdomain: "acme", Region: "EU"}, "https://api.eu.onelogin.com"},
+		{api.Config{Subdomain: "acme", Region: "eu", BaseURL: "https://sso.example.com/"}, "https://sso.example.com"},
+	}
+	for _, c := range cases {
+		got, err := c.cfg.ResolveBaseURL()
+		if err != nil || got != c.expected {
+			t.Errorf("ResolveBaseURL(%+v) = %q, %v; want %q", c.cfg, got, err, c.expected)
+		}
+	}
+	if _, err := (api.Config{}).ResolveBaseURL(); err == nil {
+		t.Errorf("Expected an error without subdomain, region or base URL")
+	}
+}
+
+func TestNewOneloginSDKWithBaseURL(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/auth/oauth2/v2/token":
+			w.Write([]byte(`{"access_token":"local-token"}`))
+		case "/api/2/users/42":
+			if r.Header.Get("Authorization") != "Bearer local-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"id":42}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	sdk, err := onelogin.NewOneloginSDK(
+		api.WithBaseURL(server.URL),
+		api.WithCredentials("id", "secret"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sdk.GetUserByID(42, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"POST /auth/oauth2/v2/token", "GET /api/2/users/42"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected requests %v, got %v", expected, paths)
+	}
+}
+
+func writeCredentialsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	content := `[default]
+subdomain = acme
+client_id = default-id
+client_secret = default-secret
+
+[profile sandbox]
+subdomain = acme-sandbox
+region = eu
+client_id = sandbox-id
+client_secret = sandbox-secret
+timeout = 30
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(authentication.EnvCredentialsFile, path)
+}
+
+func TestConfigFromProfile(t *testing.T) {
+	writeCredentialsFile(t)
+	t.Setenv(authentication.EnvProfile, "")
+
+	cfg, err := api.ConfigFromProfile("sandbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Subdomain != "acme-sandbox" || cfg.Region != "eu" || cfg.ClientID != "sandbox-id" ||
+		cfg.ClientSecret != "sandbox-secret" || cfg.Timeout != 30*time.Second {
+		t.Fatalf("Unexpected sandbox config: %+v", cfg)
+	}
+
+	cfg, err = api.ConfigFromProfile("")
+	if err != nil || cfg.ClientID != "default-id" {
+		t.Fatalf("Expected the default profile, got %+v (%v)", cfg, err)
+	}
+
+	if _, err := api.ConfigFromProfile("prod"); err == nil {
+		t.Fatalf("Expected an error for a missing profile")
+	}
+}
+
+func TestConfigFromEnvSelectsProfile(t *testing.T) {
+	writeCredentialsFile(t)
+	t.Setenv(authentication.EnvProfile, "sandbox")
+	t.Setenv(api.EnvSubdomain, "")
+	t.Setenv(api.EnvClientID, "env-id")
+	t.Setenv(api.EnvClientSecret, "")
+
+	cfg := api.ConfigFromEnv()
+	if cfg.Subdomain != "acme-sandbox" || cfg.ClientSecret != "sandbox-secret" {
+		t.Fatalf("Expected values from the sandbox profile, got %+v", cfg)
+	}
+	if cfg.ClientID != "env-id" {
+		t.Fatalf("Expected environment variables to override the profile, got %+v", cfg)
+	}
+}
+
+func TestNewOneloginSDKWithMissingProfile(t *testing.T) {
+	writeCredentialsFile(t)
+
+	if _, err := onelogin.NewOneloginSDK(api.WithProfile("prod")); err == nil {
+		t.Fatalf("Expected an error for a missing profile")
+	}
+}
+
+// newOptionsSDK builds an SDK against server from options, the way callers configure it.
+func newOptionsSDK(t *testing.T, server *onelogintest.Server, opts ...api.Option) *onelogin.OneloginSDK {
+	t.Helper()
+	opts = append([]api.Option{
+		api.WithBaseURL(server.URL),
+		api.WithCredentials(onelogintest.ClientID, onelogintest.ClientSecret),
+		api.WithHTTPClient(server.Client()),
+	}, opts...)
+	sdk, err := onelogin.NewOneloginSDK(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sdk
+}
+
+func TestWithRetryPolicyOption(t *testing.T) {
+	server := newFakeServer(t)
+
+	noRetry := newOptionsSDK(t, server, api.WithRetryPolicy(nil))
+	server.FailNext(1, http.StatusServiceUnavailable)
+	if _, err := noRetry.GetUsers(&models.UserQuery{}); err == nil {
+		t.Fatal("Expected the 503 to surface without a retry")
+	}
+
+	fast := newOptionsSDK(t, server, api.WithRetryPolicy(&api.RetryPolicy{
+		MaxAttempts:       2,
+		BaseDelay:         time.Millisecond,
+		MaxDelay:          time.Millisecond,
+		RetryableStatuses: map[int]bool{http.StatusServiceUnavailable: true},
+	}))
+	server.FailNext(1, http.StatusServiceUnavailable)
+	if _, err := fast.GetUsers(&models.UserQuery{}); err != nil {
+		t.Fatalf("Expected the configured policy to retry the 503, got %v", err)
+	}
+}
+
+func TestWithThrottleOption(t *testing.T) {
+	server := newFakeServer(t)
+	sdk := newOptionsSDK(t, server, api.WithRetryPolicy(nil), api.WithThrottle(true))
+
+	server.RateLimitNext(1, 0)
+	if _, err := sdk.GetUsers(&models.UserQuery{}); err == nil {
+		t.Fatal("Expected the rate limited request to fail")
+	}
+	sent := len(server.Requests())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := sdk.GetUsersWithContext(ctx, &models.UserQuery{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the throttled request to wait for the budget, got %v", err)
+	}
+	if got := len(server.Requests()); got != sent {
+		t.Fatalf("Expected the throttled request to stay local, server saw %d requests after %d", got, sent)
+	}
+}
+
+func TestWithLogBodiesOption(t *testing.T) {
+	server := newFakeServer(t)
+	logger := &recordingLogger{}
+	sdk := newOptionsSDK(t, server, api.WithLogger(logger), api.WithLogBodies(true))
+
+	_, err := sdk.CreateUser(models.User{Email: "jane@example.com", Username: "jane", Password: "hunter2", PasswordConfirmation: "hunter2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := logger.String()
+	if !strings.Contains(out, "jane@example.com") {
+		t.Fatalf("Expected the request body to be logged:\n%s", out)
+	}
+	if strings.Contains(out, "hunter2") {
+		t.Fatalf("Expected the password to be redacted:\n%s", out)
+	}
+}
@@ -1,7 +1,11 @@
 package tests
 
 import (
+	"bytes"
 	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
@@ -84,3 +88,69 @@ func TestSDKError_Is(t *testing.T) {
 		}
 	})
 }
+
+func newErrorResponse(status int, body string) *http.Response {
+	header := http.Header{}
+	header.Set("X-Request-Id", "req-42")
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		Request:    &http.Request{Method: http.MethodPost, URL: &url.URL{Path: "/api/2/users"}},
+	}
+}
+
+func TestNewAPIErrorFromResponse(t *testing.T) {
+	t.Run("v2 validation error", func(t *testing.T) {
+		resp := newErrorResponse(http.StatusUnprocessableEntity, `{"statusCode":422,"name":"UnprocessableEntityError","message":"Validation Failed","errors":[{"field":"email","message":["is invalid","is taken"]}]}`)
+		apiErr := error.NewAPIErrorFromResponse(resp)
+
+		if apiErr.StatusCode != 422 || apiErr.Code != 422 || apiErr.Name != "UnprocessableEntityError" || apiErr.Message != "Validation Failed" {
+			t.Fatalf("unexpected error: %+v", apiErr)
+		}
+		if len(apiErr.Errors) != 1 || apiErr.Errors[0].Field != "email" || len(apiErr.Errors[0].Messages) != 2 {
+			t.Fatalf("unexpected field errors: %+v", apiErr.Errors)
+		}
+		if apiErr.Method != http.MethodPost || apiErr.Path != "/api/2/users" || apiErr.RequestID != "req-42" {
+			t.Fatalf("unexpected request details: %+v", apiErr)
+		}
+		expected := "API error: 422 UnprocessableEntityError: Validation Failed; email: is invalid, is taken (POST /api/2/users) [request id req-42]"
+		if apiErr.Error() != expected {
+			t.Errorf("unexpected message: got %q, want %q", apiErr.Error(), expected)
+		}
+	})
+
+	t.Run("v1 status error", func(t *testing.T) {
+		resp := newErrorResponse(http.StatusUnauthorized, `{"status":{"error":true,"code":401,"type":"Unauthorized","message":"Authentication Failure"}}`)
+		apiErr := error.NewAPIErrorFromResponse(resp)
+
+		if apiErr.Code != 401 || apiErr.Name != "Unauthorized" || apiErr.Message != "Authentication Failure" {
+			t.Fatalf("unexpected error: %+v", apiErr)
+		}
+	})
+
+	t.Run("body without details", func(t *testing.T) {
+		resp := newErrorResponse(http.StatusBadGateway, `<html>Bad Gateway</html>`)
+		apiErr := error.NewAPIErrorFromResponse(resp)
+
+		if apiErr.StatusCode != 502 || apiErr.Message != "Bad Gateway" || apiErr.Body != "<html>Bad Gateway</html>" {
+			t.Fatalf("unexpected error: %+v", apiErr)
+		}
+	})
+}
+
+func TestResourceMethodReturnsAPIError(t *testing.T) {
+	sdk := createMockSDK(http.StatusNotFound, `{"statusCode":404,"name":"NotFound","message":"User not found"}`, nil)
+
+	_, err := sdk.GetUserByID(7, nil)
+	var apiErr *error.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "User not found" {
+		t.Fatalf("unexpected error: %+v", apiErr)
+	}
+	if apiErr.Method != http.MethodGet || apiErr.Path != "/api/2/users/7" {
+		t.Fatalf("expected the request to be recorded, got %q %q", apiErr.Method, apiErr.Path)
+	}
+}
@@ -0,0 +1,215 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+// eventLog answers event list requests with the events created since the requested time.
+type eventLog struct {
+	mu       sync.Mutex
+	events   []models.Event
+	requests int
+}
+
+func (l *eventLog) add(id int64, createdAt time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, models.Event{ID: id, CreatedAt: createdAt, EventTypeID: models.EventTypeUserFailedAuthentication})
+}
+
+func (l *eventLog) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.requests
+}
+
+func (l *eventLog) sdk() *onelogin.OneloginSDK {
+	client := createMockClient()
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.requests++
+		since, _ := time.Parse(time.RFC3339, req.URL.Query().Get("since"))
+		matched := []models.Event{}
+		for _, e := range l.events {
+			if !e.CreatedAt.Before(since) {
+				matched = append(matched, e)
+			}
+		}
+		data, _ := json.Marshal(matched)
+		body := `{"status":{"error":false,"code":200},"pagination":{"after_cursor":null},"data":` + string(data) + `}`
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+	}
+	return onelogin.NewOneloginSDKWithClient(client)
+}
+
+func receive(t *testing.T, events <-chan models.Event, n int) []int64 {
+	t.Helper()
+	var ids []int64
+	for len(ids) < n {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				t.Fatalf("Stream closed after %v", ids)
+			}
+			ids = append(ids, e.ID)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out after %v", ids)
+		}
+	}
+	return ids
+}
+
+func TestTailDeliversNewEventsOnce(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	log := &eventLog{}
+	log.add(1, start.Add(-time.Minute)) // before the start of the stream
+	log.add(3, start.Add(time.Second))
+	log.add(2, start.Add(time.Second))
+
+	store := &onelogin.MemoryCheckpointStore{}
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := log.sdk().Events.Tail(ctx, onelogin.TailOptions{
+		Query:    &models.EventQuery{EventTypeID: intPtr(models.EventTypeUserFailedAuthentication)},
+		Interval: 5 * time.Millisecond,
+		Store:    store,
+		Start:    start,
+		OnError:  func(err error) { t.Error(err) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := receive(t, events, 2); ids[0] != 2 || ids[1] != 3 {
+		t.Fatalf("Expected events 2 and 3 in creation order, got %v", ids)
+	}
+	// Later polls return events 2 and 3 again, since they were created at the high-water mark.
+	log.add(4, start.Add(time.Second))
+	log.add(5, start.Add(2*time.Second))
+	if ids := receive(t, events, 2); ids[0] != 4 || ids[1] != 5 {
+		t.Fatalf("Expected events 4 and 5, got %v", ids)
+	}
+	cancel()
+	for e := range events {
+		t.Fatalf("Unexpected event %d after cancellation", e.ID)
+	}
+
+	cp, _ := store.Load(context.Background())
+	if !cp.Since.Equal(start.Add(2*time.Second)) || len(cp.SeenIDs) != 1 || cp.SeenIDs[0] != 5 {
+		t.Fatalf("Unexpected checkpoint %+v", cp)
+	}
+
+	// A restarted stream resumes from the checkpoint.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	events, err = log.sdk().Events.Tail(ctx, onelogin.TailOptions{Interval: 5 * time.Millisecond, Store: store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.add(6, start.Add(3*time.Second))
+	if ids := receive(t, events, 1); ids[0] != 6 {
+		t.Fatalf("Expected only event 6 after the restart, got %v", ids)
+	}
+}
+
+func TestTailBackpressure(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	log := &eventLog{}
+	for i := int64(1); i <= 3; i++ {
+		log.add(i, start.Add(time.Duration(i)*time.Second))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := log.sdk().Events.Tail(ctx, onelogin.TailOptions{Interval: time.Millisecond, Start: start})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if n := log.count(); n != 1 {
+		t.Fatalf("Expected the poller to wait for the consumer after the first poll, got %d polls", n)
+	}
+	if ids := receive(t, events, 3); ids[2] != 3 {
+		t.Fatalf("Expected events 1 to 3, got %v", ids)
+	}
+}
+
+func TestTailSavesCheckpointPerPage(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	store := &onelogin.MemoryCheckpointStore{}
+
+	var mu sync.Mutex
+	var beforeSecondPage *onelogin.Checkpoint
+	client := createMockClient()
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		body := `{"status":{"error":false,"code":200},"pagination":{"after_cursor":null},"data":[]}`
+		switch req.URL.Query().Get("after_cursor") {
+		case "":
+			if req.URL.Query().Get("since") == start.Format(time.RFC3339) {
+				body = `{"status":{"error":false,"code":200},"pagination":{"after_cursor":"page-2"},"data":[{"id":2,"created_at":"2024-03-01T10:00:02Z"},{"id":1,"created_at":"2024-03-01T10:00:01Z"}]}`
+			}
+		case "page-2":
+			cp, _ := store.Load(context.Background())
+			mu.Lock()
+			beforeSecondPage = &cp
+			mu.Unlock()
+			body = `{"status":{"error":false,"code":200},"pagination":{"after_cursor":null},"data":[{"id":3,"created_at":"2024-03-01T10:00:03Z"}]}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(body))}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := onelogin.NewOneloginSDKWithClient(client).Events.Tail(ctx, onelogin.TailOptions{
+		Interval: time.Hour,
+		Store:    store,
+		Start:    start,
+		Buffer:   3,
+		OnError:  func(err error) { t.Error(err) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := receive(t, events, 3); ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Fatalf("Expected events 1 to 3 in order, got %v", ids)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if beforeSecondPage == nil || !beforeSecondPage.Since.Equal(start.Add(2*time.Second)) || len(beforeSecondPage.SeenIDs) != 1 || beforeSecondPage.SeenIDs[0] != 2 {
+		t.Fatalf("Expected the first page to be checkpointed before the second was fetched, got %+v", beforeSecondPage)
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	store := onelogin.FileCheckpointStore{Path: filepath.Join(t.TempDir(), "events.json")}
+	cp, err := store.Load(context.Background())
+	if err != nil || !cp.Since.IsZero() {
+		t.Fatalf("Expected an empty checkpoint, got %+v, %v", cp, err)
+	}
+
+	saved := onelogin.Checkpoint{Since: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), SeenIDs: []int64{7, 8}}
+	if err := store.Save(context.Background(), saved); err != nil {
+		t.Fatal(err)
+	}
+	cp, err = store.Load(context.Background())
+	if err != nil || !cp.Since.Equal(saved.Since) || len(cp.SeenIDs) != 2 {
+		t.Fatalf("Expected %+v, got %+v, %v", saved, cp, err)
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+func TestListEventsSendsFilters(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200,"type":"success","message":"Success"},
+		"pagination":{"before_cursor":null,"after_cursor":null,"previous_link":null,"next_link":null},
+		"data":[{"id":999000111222,"created_at":"2024-03-01T10:00:00Z","account_id":7,"event_type_id":6,"user_id":42,"user_name":"Jane","ipaddr":"10.0.0.1","notes":"Wrong password"}]}`, &requests)
+
+	eventType, userID, directoryID := models.EventTypeUserFailedAuthentication, 42, 3
+	resolution := "unresolved"
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	events, err := sdk.ListEvents(&models.EventQuery{
+		EventTypeID: &eventType,
+		UserID:      &userID,
+		DirectoryID: &directoryID,
+		Resolution:  &resolution,
+		Since:       &since,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != 999000111222 || events[0].EventTypeID != 6 || events[0].IPAddr != "10.0.0.1" || events[0].CreatedAt.IsZero() {
+		t.Fatalf("Unexpected events: %+v", events)
+	}
+
+	q := requests[0].URL.Query()
+	if requests[0].URL.Path != "/api/1/events" || q.Get("event_type_id") != "6" || q.Get("user_id") != "42" ||
+		q.Get("directory_id") != "3" || q.Get("resolution") != "unresolved" || q.Get("since") != "2024-03-01T00:00:00Z" {
+		t.Fatalf("Unexpected request %s", requests[0].URL)
+	}
+}
+
+func TestGetEvent(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200},"data":[{"id":4294967308,"event_type_id":13,"user_name":"Jane"}]}`, &requests)
+
+	// Event ids outgrow 32 bits, so GetEvent takes the int64 of models.Event.ID.
+	event, err := sdk.GetEvent(4294967308)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.ID != 4294967308 || event.EventTypeID != models.EventTypeUserCreated || requests[0].URL.Path != "/api/1/events/4294967308" {
+		t.Fatalf("Unexpected event %+v from %s", event, requests[0].URL)
+	}
+
+	sdk = createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200},"data":[]}`, nil)
+	_, err = sdk.GetEvent(13)
+	var sdkErr olerror.SDKError
+	if !errors.As(err, &sdkErr) || olerror.IsNotFound(err) {
+		t.Fatalf("Expected an SDK error rather than a not found API error, got %v", err)
+	}
+}
+
+func TestListEventTypes(t *testing.T) {
+	sdk := createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200},"data":[{"id":5,"name":"USER_LOGGED_INTO_ONELOGIN","description":"%user% logged into OneLogin"}]}`, nil)
+
+	types, err := sdk.ListEventTypes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(types) != 1 || types[0].ID != models.EventTypeUserLoggedIntoOneLogin || types[0].Name != "USER_LOGGED_INTO_ONELOGIN" {
+		t.Fatalf("Unexpected event types: %+v", types)
+	}
+}
+
+func TestEventsListFollowsEnvelopeCursor(t *testing.T) {
+	var cursors []string
+	sdk := createPagedSDK(func(req *http.Request) (http.Header, string) {
+		cursor := req.URL.Query().Get("after_cursor")
+		cursors = append(cursors, cursor)
+		if cursor == "" {
+			return http.Header{}, `{"status":{"error":false,"code":200},"pagination":{"after_cursor":"c1","next_link":"https://api.onelogin.com/api/1/events?after_cursor=c1"},"data":[{"id":1},{"id":2}]}`
+		}
+		return http.Header{}, `{"status":{"error":false,"code":200},"pagination":{"after_cursor":null,"next_link":null},"data":[{"id":3}]}`
+	})
+
+	events, err := sdk.Events.List(context.Background(), &models.EventQuery{Limit: "2"}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 || events[2].ID != 3 {
+		t.Fatalf("Expected 3 events, got %+v", events)
+	}
+	if len(cursors) != 2 || cursors[1] != "c1" {
+		t.Fatalf("Expected the second page to be requested with after_cursor=c1, got %v", cursors)
+	}
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+func TestGenerateInviteLink(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200,"type":"success","message":"Success"},"data":["https://example.onelogin.com/password/reset/abc123"]}`, &requests)
+
+	link, err := sdk.GenerateInviteLink("jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link != "https://example.onelogin.com/password/reset/abc123" {
+		t.Fatalf("Unexpected link %q", link)
+	}
+	body, _ := ioutil.ReadAll(requests[0].Body)
+	if requests[0].URL.Path != "/api/1/invites/get_invite_link" || string(body) != `{"email":"jane@example.com"}` {
+		t.Fatalf("Unexpected request %s with body %s", requests[0].URL, body)
+	}
+
+	sdk = createMockSDK(http.StatusBadRequest, `{"status":{"error":true,"code":400,"type":"bad request","message":"Email is not valid"}}`, nil)
+	_, err = sdk.GenerateInviteLink("jane")
+	var apiErr *olerror.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "Email is not valid" {
+		t.Fatalf("Expected a bad request error, got %v", err)
+	}
+
+	sdk = createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200},"data":[]}`, nil)
+	_, err = sdk.GenerateInviteLink("jane@example.com")
+	var sdkErr olerror.SDKError
+	if !errors.As(err, &sdkErr) || olerror.IsNotFound(err) {
+		t.Fatalf("Expected an SDK error for an empty response, got %v", err)
+	}
+}
+
+func TestSendInviteLinkToPersonalEmail(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200,"type":"success","message":"Success"}}`, &requests)
+
+	err := sdk.SendInviteLink(models.SendInviteLinkRequest{Email: "jane@example.com", PersonalEmail: "jane@home.example"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(requests[0].Body)
+	if requests[0].URL.Path != "/api/1/invites/send_invite_link" || string(body) != `{"email":"jane@example.com","personal_email":"jane@home.example"}` {
+		t.Fatalf("Unexpected request %s with body %s", requests[0].URL, body)
+	}
+}
@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+// recordingLogger keeps every message with its arguments formatted as "msg key=value ...".
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (l *recordingLogger) record(level, msg string, args []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := level + " " + msg
+	for i := 0; i+1 < len(args); i += 2 {
+		entry += fmt.Sprintf(" %v=%v", args[i], args[i+1])
+	}
+	l.entries = append(l.entries, entry)
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.record("DEBUG", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...any)  { l.record("INFO", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...any)  { l.record("WARN", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...any) { l.record("ERROR", msg, args) }
+
+func (l *recordingLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.entries, "\n")
+}
+
+func TestClientLogsRedactedRequests(t *testing.T) {
+	logger := &recordingLogger{}
+	client := createMockClient()
+	client.Logger = logger
+	client.LogBodies = true
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Header:     http.Header{"Set-Cookie": []string{"session=abc"}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":1,"email":"jane@example.com","password":"hunter2"}`)),
+		}, nil
+	}
+
+	user, _, err := api.Do[*models.User](context.Background(), client, http.MethodPost, "/api/2/users", nil,
+		models.User{Email: "jane@example.com", Password: "hunter2", PasswordConfirmation: "hunter2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != "jane@example.com" {
+		t.Fatalf("Expected the logged response body to remain readable, got %+v", user)
+	}
+
+	out := logger.String()
+	for _, secret := range []string{"hunter2", "mockToken", "session=abc"} {
+		if strings.Contains(out, secret) {
+			t.Fatalf("Expected %q to be redacted from the log:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "DEBUG request method=POST path=/api/2/users") || !strings.Contains(out, "jane@example.com") || !strings.Contains(out, "status=201") {
+		t.Fatalf("Expected request and response debug entries:\n%s", out)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	body := api.RedactBody("/api/2/hooks/envs", []byte(`{"name":"API_KEY","value":"s3cret"}`))
+	if strings.Contains(body, "s3cret") || !strings.Contains(body, "API_KEY") {
+		t.Fatalf("Expected the environment variable value to be redacted, got %s", body)
+	}
+
+	body = api.RedactBody("/auth/oauth2/v2/token", []byte(`{"client_id":"id","client_secret":"xyz","nested":[{"refresh_token":"r"}]}`))
+	if strings.Contains(body, "xyz") || strings.Contains(body, `"r"`) || !strings.Contains(body, `"client_id":"id"`) {
+		t.Fatalf("Expected credentials to be redacted, got %s", body)
+	}
+
+	if body := api.RedactBody("/api/2/users", []byte("not json")); body != "[8 bytes]" {
+		t.Fatalf("Expected a size placeholder for non JSON bodies, got %s", body)
+	}
+}
+
+func TestStdLoggerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := api.NewStdLogger(log.New(&buf, "", 0), api.LevelWarn)
+
+	logger.Info("ignored", "key", "value")
+	logger.Warn("retrying request", "attempt", 2, "odd")
+
+	if out := buf.String(); out != "WARN retrying request attempt=2 !BADKEY=odd\n" {
+		t.Fatalf("Unexpected log output: %q", out)
+	}
+}
@@ -0,0 +1,148 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/onelogintest"
+)
+
+func TestMiddlewareWrapsRetries(t *testing.T) {
+	client := createMockClient()
+	client.Retry = &api.RetryPolicy{
+		MaxAttempts:        3,
+		BaseDelay:          time.Millisecond,
+		RetryableStatuses:  map[int]bool{http.StatusServiceUnavailable: true},
+		RetryNonIdempotent: true,
+	}
+
+	attempts := 0
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		attempts++
+		if tenant := req.Header.Get("X-Tenant"); tenant != "acme" {
+			t.Fatalf("Attempt %d: expected the tenant header, got %q", attempts, tenant)
+		}
+		status := http.StatusServiceUnavailable
+		if attempts == 2 {
+			status = http.StatusCreated
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":7,"email":"jane@example.com"}`)),
+		}, nil
+	}
+
+	var calls []string
+	client.Use(
+		api.BeforeRequest(func(req *http.Request) error {
+			req.Header.Set("X-Tenant", "acme")
+			body, err := api.ReadRequestBody(req)
+			if err != nil {
+				return err
+			}
+			calls = append(calls, "before "+req.Method+" "+req.URL.Path+" "+string(body))
+			return nil
+		}),
+		api.AfterResponse(func(req *http.Request, resp *http.Response, err error) {
+			body, _ := api.ReadResponseBody(resp)
+			calls = append(calls, "after "+http.StatusText(resp.StatusCode)+" "+string(body))
+		}),
+	)
+
+	user, _, err := api.Do[*models.User](context.Background(), client, http.MethodPost, "/api/2/users", nil, map[string]string{"email": "jane@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 7 {
+		t.Fatalf("Expected the response body to remain readable, got %+v", user)
+	}
+
+	expected := []string{
+		`before POST /api/2/users {"email":"jane@example.com"}`,
+		`after Created {"id":7,"email":"jane@example.com"}`,
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("Expected one call around both attempts %v, got %v", expected, calls)
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	client := createMockClient()
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+	}
+
+	var order []string
+	layer := func(name string) api.Middleware {
+		return func(next api.RoundTripFunc) api.RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" in")
+				resp, err := next(req)
+				order = append(order, name+" out")
+				return resp, err
+			}
+		}
+	}
+	client.Use(layer("outer"))
+	client.Use(layer("inner"))
+
+	if _, err := client.Get(new(string), nil); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"outer in", "inner in", "inner out", "outer out"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("Expected %v, got %v", expected, order)
+	}
+}
+
+func TestBeforeRequestAbortsRequest(t *testing.T) {
+	client := createMockClient()
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		t.Fatal("Expected the request not to be sent")
+		return nil, nil
+	}
+
+	denied := errors.New("tenant not allowed")
+	client.Use(api.BeforeRequest(func(req *http.Request) error {
+		return denied
+	}))
+
+	if _, err := client.Get(new(string), nil); !errors.Is(err, denied) {
+		t.Fatalf("Expected the hook error, got %v", err)
+	}
+}
+
+func TestWithMiddlewareOption(t *testing.T) {
+	server := newFakeServer(t)
+
+	var paths []string
+	sdk, err := onelogin.NewOneloginSDK(
+		api.WithBaseURL(server.URL),
+		api.WithCredentials(onelogintest.ClientID, onelogintest.ClientSecret),
+		api.WithHTTPClient(server.Client()),
+		api.WithMiddleware(api.BeforeRequest(func(req *http.Request) error {
+			paths = append(paths, req.URL.Path)
+			return nil
+		})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sdk.GetUsers(&models.UserQuery{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || paths[0] != "/api/2/users" {
+		t.Fatalf("Expected the middleware to see the API call, got %v", paths)
+	}
+}
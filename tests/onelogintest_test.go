@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/onelogintest"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func newFakeServer(t *testing.T) *onelogintest.Server {
+	server := onelogintest.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFakeServerUserLifecycle(t *testing.T) {
+	server := newFakeServer(t)
+	sdk, err := server.SDK()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := sdk.CreateUser(models.User{Email: "jane@example.com", Firstname: "Jane"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == 0 || created.Email != "jane@example.com" {
+		t.Fatalf("Unexpected created user %+v", created)
+	}
+
+	updated, err := sdk.UpdateUser(int(created.ID), models.User{Lastname: "Doe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Firstname != "Jane" || updated.Lastname != "Doe" {
+		t.Fatalf("Expected the update to be merged, got %+v", updated)
+	}
+
+	found, err := sdk.GetUsers(&models.UserQuery{Email: &created.Email})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ID != created.ID {
+		t.Fatalf("Expected to find the user by email, got %+v", found)
+	}
+
+	if err := sdk.DeleteUser(int(created.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sdk.GetUserByID(int(created.ID), nil); !olerror.IsNotFound(err) {
+		t.Fatalf("Expected a not found error after deletion, got %v", err)
+	}
+	if _, err := sdk.CreateUser(models.User{Firstname: "Anonymous"}); err == nil {
+		t.Fatal("Expected a validation error for a user without email or username")
+	}
+}
+
+func TestFakeServerPagination(t *testing.T) {
+	server := newFakeServer(t)
+	for i := 0; i < 5; i++ {
+		if _, err := server.AddRole(models.Role{Name: stringPtr("role")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sdk, err := server.SDK()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pager := sdk.Roles.List(context.Background(), &models.RoleQuery{Limit: "2"})
+	pages := 0
+	var roles []models.Role
+	for pager.NextPage() {
+		pages++
+		roles = append(roles, pager.Page()...)
+		if pager.Response().TotalCount != 5 {
+			t.Fatalf("Expected a total count of 5, got %d", pager.Response().TotalCount)
+		}
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if pages != 3 || len(roles) != 5 || *roles[4].ID != 5 {
+		t.Fatalf("Expected 5 roles on 3 pages, got %d roles on %d pages", len(roles), pages)
+	}
+}
+
+func TestFakeServerRoleMembers(t *testing.T) {
+	server := newFakeServer(t)
+	user, err := server.AddUser(models.User{Email: "jane@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	role, err := server.AddRole(models.Role{Name: stringPtr("admins")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdk, err := server.SDK()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := fmt.Sprintf("/api/2/roles/%d/users", *role.ID)
+	resp, err := sdk.Client.Post(&path, []int{int(user.ID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	members, err := sdk.GetRoleUsers(int(*role.ID), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].Email != "jane@example.com" {
+		t.Fatalf("Expected the user to be a member of the role, got %+v", members)
+	}
+
+	if err := sdk.DeleteRoleUsers(int(*role.ID), []int{int(user.ID)}); err != nil {
+		t.Fatal(err)
+	}
+	if members, _ := sdk.GetRoleUsers(int(*role.ID), nil); len(members) != 0 {
+		t.Fatalf("Expected the role to have no members, got %+v", members)
+	}
+}
+
+func TestFakeServerFailureInjection(t *testing.T) {
+	server := newFakeServer(t)
+	if _, err := server.AddApp(models.App{Name: stringPtr("Slack")}); err != nil {
+		t.Fatal(err)
+	}
+	sdk, err := server.SDK()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server.RateLimitNext(1, 0)
+	apps, err := sdk.GetApps(nil)
+	if err != nil || len(apps) != 1 {
+		t.Fatalf("Expected the rate limited request to be retried, got %v, %v", apps, err)
+	}
+
+	sdk.Client.Retry = nil
+	server.RateLimitNext(1, time.Second)
+	if _, err := sdk.GetApps(nil); !olerror.IsRateLimited(err) {
+		t.Fatalf("Expected a rate limit error, got %v", err)
+	}
+	if rl, ok := sdk.RateLimit(); !ok || rl.Remaining != 0 {
+		t.Fatalf("Expected an exhausted rate limit budget, got %+v", rl)
+	}
+
+	server.ExpireTokens()
+	if _, err := sdk.GetApps(nil); err != nil {
+		t.Fatalf("Expected the token to be renewed, got %v", err)
+	}
+	if n := server.Len(onelogintest.Apps); n != 1 {
+		t.Fatalf("Expected 1 app, got %d", n)
+	}
+}
+
+func TestFakeServerRejectsWrongCredentials(t *testing.T) {
+	server := newFakeServer(t)
+	cfg := server.Config()
+	cfg.ClientSecret = "wrong"
+	if _, err := api.NewClientWithConfig(cfg); !olerror.IsUnauthorized(err) {
+		t.Fatalf("Expected an authentication error, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/utilities"
+)
+
+// createPagedSDK returns an SDK answering every request with the page picked by respond.
+func createPagedSDK(respond func(req *http.Request) (http.Header, string)) *onelogin.OneloginSDK {
+	client := createMockClient()
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		header, body := respond(req)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}, nil
+	}
+	return onelogin.NewOneloginSDKWithClient(client)
+}
+
+func TestUsersListFollowsCursors(t *testing.T) {
+	var queries []string
+	sdk := createPagedSDK(func(req *http.Request) (http.Header, string) {
+		queries = append(queries, req.URL.RawQuery)
+		header := http.Header{}
+		switch req.URL.Query().Get("cursor") {
+		case "":
+			header.Set(api.AfterCursorHeader, "c1")
+			return header, `[{"id":1},{"id":2}]`
+		case "c1":
+			header.Set(api.AfterCursorHeader, "c2")
+			return header, `[{"id":3}]`
+		default:
+			return header, `[{"id":4}]`
+		}
+	})
+
+	limit := "2"
+	email := "a@example.com"
+	users, err := sdk.Users.List(context.Background(), &models.UserQuery{Limit: limit, Email: &email}).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 4 || users[3].ID != 4 {
+		t.Fatalf("Expected 4 users across 3 pages, got %+v", users)
+	}
+	expected := []string{"email=a%40example.com&limit=2", "cursor=c1&email=a%40example.com&limit=2", "cursor=c2&email=a%40example.com&limit=2"}
+	if fmt.Sprint(queries) != fmt.Sprint(expected) {
+		t.Fatalf("Unexpected queries: %v", queries)
+	}
+}
+
+func TestRolesListFollowsPageNumbers(t *testing.T) {
+	calls := 0
+	sdk := createPagedSDK(func(req *http.Request) (http.Header, string) {
+		calls++
+		page := req.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		header := http.Header{}
+		header.Set(api.CurrentPageHeader, page)
+		header.Set(api.TotalPagesHeader, "2")
+		return header, fmt.Sprintf(`[{"id":%s}]`, page)
+	})
+
+	pager := sdk.Roles.List(context.Background(), nil)
+	var pages [][]models.Role
+	for pager.NextPage() {
+		pages = append(pages, pager.Page())
+		if pager.Response().TotalPages != 2 {
+			t.Fatalf("Unexpected page metadata: %+v", pager.Response())
+		}
+	}
+	if err := pager.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 || *pages[1][0].ID != 2 || calls != 2 {
+		t.Fatalf("Expected 2 pages in 2 calls, got %v after %d calls", pages, calls)
+	}
+}
+
+func TestPagerItemIteration(t *testing.T) {
+	sdk := createPagedSDK(func(req *http.Request) (http.Header, string) {
+		header := http.Header{}
+		if req.URL.Query().Get("cursor") == "" {
+			header.Set(api.LinkHeader, `<https://api.onelogin.com/api/2/hooks?cursor=next>; rel="next"`)
+			return header, `[{"id":"a"},{"id":"b"}]`
+		}
+		return header, `[{"id":"c"}]`
+	})
+
+	var ids []string
+	pager := sdk.Hooks.List(context.Background(), &models.SmartHookQuery{})
+	for pager.Next() {
+		ids = append(ids, *pager.Item().ID)
+	}
+	if pager.Err() != nil || fmt.Sprint(ids) != "[a b c]" {
+		t.Fatalf("Unexpected iteration: %v (%v)", ids, pager.Err())
+	}
+}
+
+func TestPagerStopsOnError(t *testing.T) {
+	client := createMockClient()
+	calls := 0
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls > 1 {
+			return &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}, Body: ioutil.NopCloser(bytes.NewBufferString(`{}`))}, nil
+		}
+		header := http.Header{}
+		header.Set(api.AfterCursorHeader, "next")
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(bytes.NewBufferString(`[{"id":1}]`))}, nil
+	}
+	sdk := onelogin.NewOneloginSDKWithClient(client)
+
+	apps, err := sdk.Apps.List(context.Background(), nil).All()
+	if err == nil {
+		t.Fatalf("Expected the failing page to stop the iteration")
+	}
+	if len(apps) != 1 {
+		t.Fatalf("Expected the items fetched before the failure, got %+v", apps)
+	}
+}
+
+func TestAddQueryToPathEncodesQueryFields(t *testing.T) {
+	connector := 42
+	path, err := utilities.AddQueryToPath("/api/2/apps", &models.AppQuery{Limit: "10", ConnectorID: &connector})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != "/api/2/apps?connector_id=42&limit=10" {
+		t.Fatalf("Unexpected path: %s", path)
+	}
+}
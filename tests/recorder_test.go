@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/onelogintest"
+)
+
+// provisionUser is the code under test in the record/replay tests.
+func provisionUser(sdk *onelogin.OneloginSDK, email string) (*models.User, error) {
+	created, err := sdk.CreateUser(models.User{Email: email, Password: "Sup3rSecret!", PasswordConfirmation: "Sup3rSecret!"})
+	if err != nil {
+		return nil, err
+	}
+	return sdk.GetUserByID(int(created.ID), nil)
+}
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	server := newFakeServer(t)
+	cassette := filepath.Join(t.TempDir(), "cassettes", "provision.json")
+
+	recorder, err := onelogintest.NewRecorder(cassette, onelogintest.ModeAuto, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorder.Mode() != onelogintest.ModeRecord {
+		t.Fatalf("Expected a missing cassette to be recorded")
+	}
+	cfg := server.Config()
+	cfg.HTTPClient = recorder
+	sdk, err := onelogin.NewOneloginSDKWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recordedUser, err := provisionUser(sdk, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"Sup3rSecret!", "onelogintest-token-", onelogintest.ClientSecret, "Basic ", "Bearer "} {
+		if strings.Contains(string(b), secret) {
+			t.Fatalf("Expected %q to be scrubbed from the cassette:\n%s", secret, b)
+		}
+	}
+
+	// Replay without the server: the same calls get the recorded responses.
+	server.Close()
+	replayer, err := onelogintest.NewRecorder(cassette, onelogintest.ModeAuto, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayer.Mode() != onelogintest.ModeReplay {
+		t.Fatalf("Expected an existing cassette to be replayed")
+	}
+	cfg.BaseURL = "https://replay.invalid"
+	cfg.HTTPClient = replayer
+	sdk, err = onelogin.NewOneloginSDKWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayedUser, err := provisionUser(sdk, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayedUser.ID != recordedUser.ID || replayedUser.Email != recordedUser.Email {
+		t.Fatalf("Expected the recorded user %+v, got %+v", recordedUser, replayedUser)
+	}
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Fatalf("Expected every interaction to be replayed, got %d left", len(unused))
+	}
+}
+
+func TestRecorderRejectsUnknownRequests(t *testing.T) {
+	server := newFakeServer(t)
+	cassette := filepath.Join(t.TempDir(), "provision.json")
+
+	recorder, err := onelogintest.NewRecorder(cassette, onelogintest.ModeRecord, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.Scrub = func(i *onelogintest.Interaction) {
+		i.Request.Body = strings.ReplaceAll(i.Request.Body, "jane@example.com", "user@example.com")
+		i.Response.Body = strings.ReplaceAll(i.Response.Body, "jane@example.com", "user@example.com")
+	}
+	cfg := server.Config()
+	cfg.HTTPClient = recorder
+	sdk, err := onelogin.NewOneloginSDKWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provisionUser(sdk, "jane@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := onelogintest.NewRecorder(cassette, onelogintest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayer.Scrub = recorder.Scrub
+	cfg.HTTPClient = replayer
+	sdk, err = onelogin.NewOneloginSDKWithConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sdk.CreateUser(models.User{Email: "john@example.com"}); !errors.Is(err, onelogintest.ErrNoInteraction) {
+		t.Fatalf("Expected a request with another body not to match, got %v", err)
+	}
+	user, err := provisionUser(sdk, "jane@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != "user@example.com" {
+		t.Fatalf("Expected the scrubbed email, got %s", user.Email)
+	}
+}
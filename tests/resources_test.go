@@ -0,0 +1,271 @@
+package tests
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+// createMockSDK returns an SDK whose requests are answered with the given status and body.
+func createMockSDK(status int, body string, requests *[]*http.Request) *onelogin.OneloginSDK {
+	client := createMockClient()
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		if requests != nil {
+			*requests = append(*requests, req)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}, nil
+	}
+	return onelogin.NewOneloginSDKWithClient(client)
+}
+
+func TestGetUserByIDDecodesUser(t *testing.T) {
+	sdk := createMockSDK(http.StatusOK, `{"id":42,"email":"jane@example.com","firstname":"Jane","state":1,"status":1,"external_id":"ext-1","custom_attributes":{"team":"sdk"}}`, nil)
+
+	user, err := sdk.GetUserByID(42, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != 42 || user.Email != "jane@example.com" || user.ExternalID != "ext-1" || user.Status != models.StatusActive {
+		t.Fatalf("Unexpected user: %+v", user)
+	}
+	if user.CustomAttributes["team"] != "sdk" {
+		t.Fatalf("Unexpected custom attributes: %v", user.CustomAttributes)
+	}
+}
+
+func TestGetUsersDecodesList(t *testing.T) {
+	sdk := createMockSDK(http.StatusOK, `[{"id":1,"username":"a"},{"id":2,"username":"b"}]`, nil)
+
+	users, err := sdk.GetUsers(&models.UserQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[1].Username != "b" {
+		t.Fatalf("Unexpected users: %+v", users)
+	}
+}
+
+func TestGetGroupsUnwrapsV1Envelope(t *testing.T) {
+	sdk := createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200,"type":"success","message":"Success"},"data":[{"id":7,"name":"Engineering","reference":null}]}`, nil)
+
+	groups, err := sdk.GetGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].ID != 7 || groups[0].Name != "Engineering" {
+		t.Fatalf("Unexpected groups: %+v", groups)
+	}
+}
+
+func TestGetAppRuleByIDRequestsRulePath(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"id":9,"name":"Default","match":"all","enabled":true}`, &requests)
+
+	rule, err := sdk.GetAppRuleByID(3, 9, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.ID != 9 || rule.Name != "Default" || !rule.Enabled {
+		t.Fatalf("Unexpected rule: %+v", rule)
+	}
+	if len(requests) != 1 || requests[0].URL.Path != "/api/2/apps/3/rules/9" {
+		t.Fatalf("Unexpected request path: %s", requests[0].URL.Path)
+	}
+}
+
+func TestTypedMethodReturnsErrorOnFailureStatus(t *testing.T) {
+	sdk := createMockSDK(http.StatusNotFound, `{"statusCode":404,"name":"NotFound","message":"Not Found"}`, nil)
+
+	role, err := sdk.GetRoleByID(1, nil)
+	if err == nil {
+		t.Fatalf("Expected an error, got %+v", role)
+	}
+	if role != nil {
+		t.Fatalf("Expected no role on failure, got %+v", role)
+	}
+}
+
+func TestTypedMethodReportsMalformedBody(t *testing.T) {
+	sdk := createMockSDK(http.StatusOK, `{"id":"not-a-number"}`, nil)
+
+	if _, err := sdk.GetAppByID(1, nil); err == nil {
+		t.Fatalf("Expected a decoding error")
+	}
+}
+
+func TestV1ListsAreUnwrapped(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200,"type":"success","message":"Success"},"data":[[143424,143425]]}`, &requests)
+
+	roles, err := sdk.GetUserRoles(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 2 || roles[0] != 143424 || requests[0].URL.Path != "/api/1/users/42/roles" {
+		t.Fatalf("Unexpected roles %v from %s", roles, requests[0].URL)
+	}
+
+	sdk = createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200},"data":[["department","employee_id"]]}`, nil)
+	attributes, err := sdk.GetCustomAttributes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attributes) != 2 || attributes[1] != "employee_id" {
+		t.Fatalf("Unexpected custom attributes %v", attributes)
+	}
+}
+
+func TestV1WritesReturnOnlyErrors(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"status":{"error":false,"code":200,"type":"success","message":"Success"}}`, &requests)
+
+	if err := sdk.LockUserAccount(42); err != nil {
+		t.Fatal(err)
+	}
+	if err := sdk.SetUserState(42, 1); err != nil {
+		t.Fatal(err)
+	}
+	if requests[0].URL.Path != "/api/1/users/42/lock_user" || requests[1].URL.Path != "/api/1/users/42/set_state" {
+		t.Fatalf("Unexpected requests %s, %s", requests[0].URL, requests[1].URL)
+	}
+
+	sdk = createMockSDK(http.StatusNotFound, `{"status":{"error":true,"code":404,"type":"not found","message":"User not found"}}`, nil)
+	if err := sdk.LogOutUser(43); err == nil {
+		t.Fatal("Expected an error for a missing user")
+	}
+}
+
+func TestGetPrivilegeUsers(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"total":2,"users":[7,8],"beforeCursor":null,"afterCursor":null}`, &requests)
+
+	users, err := sdk.GetPrivilegeUsers(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[1] != 8 || requests[0].URL.Path != "/api/1/privileges/3/users" {
+		t.Fatalf("Unexpected users %v from %s", users, requests[0].URL)
+	}
+}
+
+func TestGetHookLogs(t *testing.T) {
+	sdk := createMockSDK(http.StatusOK, `[{"request_id":"req-1","created_at":"2024-03-01T10:00:00Z","logs":["started","done"],"event_payload":{"user":{"id":42}}}]`, nil)
+
+	logs, err := sdk.GetHookLogs(5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].RequestID != "req-1" || len(logs[0].Logs) != 2 || logs[0].CreatedAt == nil {
+		t.Fatalf("Unexpected logs %+v", logs)
+	}
+}
+
+func TestListMappingConditions(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `[{"name":"Last Login","value":"last_login"},{"name":"Email","value":"email"}]`, &requests)
+
+	conditions, err := sdk.ListConditions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 2 || conditions[0].Value != "last_login" {
+		t.Fatalf("Unexpected conditions %+v", conditions)
+	}
+	if _, err := sdk.ListConditionOperators("email"); err != nil {
+		t.Fatal(err)
+	}
+	if requests[1].URL.Path != "/api/2/mappings/conditions/email/operators" {
+		t.Fatalf("Unexpected request %s", requests[1].URL)
+	}
+}
+
+func TestDryrunMapping(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `[{"user":{"id":42,"email":"jane@example.com","username":"jane"},"mapped":true}]`, &requests)
+
+	users, err := sdk.DryrunMapping(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].User.ID != 42 || users[0].User.Email != "jane@example.com" || !users[0].Mapped {
+		t.Fatalf("Unexpected dry run %+v", users)
+	}
+	if requests[0].Method != http.MethodPost || requests[0].URL.Path != "/api/2/mappings/7/dryrun" {
+		t.Fatalf("Unexpected request %s %s", requests[0].Method, requests[0].URL)
+	}
+}
+
+func TestListConnectors(t *testing.T) {
+	sdk := createMockSDK(http.StatusOK, `[{"id":108419,"name":"SAML Custom Connector","icon_url":"https://example.com/icon.png","auth_method":2,"allows_new_parameters":true}]`, nil)
+
+	connectors, err := sdk.ListConnectors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(connectors) != 1 || connectors[0].ID != 108419 || connectors[0].AuthMethod != 2 || !connectors[0].AllowsNewParameters {
+		t.Fatalf("Unexpected connectors %+v", connectors)
+	}
+}
+
+func TestDeleteReturnsOnlyError(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusNoContent, ``, &requests)
+
+	if err := sdk.DeleteApp(7); err != nil {
+		t.Fatal(err)
+	}
+	if err := sdk.DeleteAuthServerScope(3, 9); err != nil {
+		t.Fatal(err)
+	}
+	if requests[0].Method != http.MethodDelete || requests[1].URL.Path != "/api/2/api_authorizations/3/scopes/9" {
+		t.Fatalf("Unexpected requests %s %s", requests[0].Method, requests[1].URL)
+	}
+
+	sdk = createMockSDK(http.StatusNotFound, `{"message":"Not found"}`, nil)
+	if err := sdk.DeleteRole(7, nil); !olerror.IsNotFound(err) {
+		t.Fatalf("Expected the 404 to be reported, got %v", err)
+	}
+}
+
+func TestMFAMethodsDecodeModels(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `[{"device_id":"1234","user_display_name":"Jane's phone","auth_factor_name":"SMS","default":true}]`, &requests)
+
+	devices, err := sdk.GetEnrolledMFAFactors(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 1 || devices[0].DeviceID != "1234" || !devices[0].Default || requests[0].URL.Path != "/api/2/mfa/users/42/devices" {
+		t.Fatalf("Unexpected devices %+v from %s", devices, requests[0].URL)
+	}
+
+	sdk = createMockSDK(http.StatusCreated, `{"mfa_token":"8675309","reusable":false,"expires_at":"2024-03-01T10:05:00Z"}`, nil)
+	token, err := sdk.GenerateMFAToken(42, models.GenerateMFATokenRequest{ExpiresIn: "300"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.MFAToken != "8675309" || token.ExpiresAt == nil {
+		t.Fatalf("Unexpected token %+v", token)
+	}
+}
+
+func TestGenerateSAMLAssertionRequiringMFA(t *testing.T) {
+	sdk := createMockSDK(http.StatusOK, `{"state_token":"st-1","message":"MFA is required for this user","devices":[{"device_id":666666,"device_type":"Google Authenticator"}],"callback_url":"https://api.onelogin.com/api/2/saml_assertion/verify_factor","user":{"id":42,"email":"jane@example.com"}}`, nil)
+
+	assertion, err := sdk.GenerateSAMLAssertion(models.GenerateSAMLTokenRequest{UsernameOrEmail: "jane@example.com", Password: "secret", AppID: "7", Subdomain: "acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assertion.Data != "" || assertion.StateToken != "st-1" || len(assertion.Devices) != 1 || assertion.Devices[0].DeviceID != 666666 || assertion.User.ID != 42 {
+		t.Fatalf("Unexpected assertion %+v", assertion)
+	}
+}
@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+)
+
+func TestRiskRuleCRUD(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"id":"8b5c0f4a-1d2e-4f3a-9b6c-7d8e9f0a1b2c","name":"Block office","type":"blacklist","target":"location.ip","filters":["10.0.0.0/8"]}`, &requests)
+
+	name, ruleType, target := "Block office", models.RiskRuleBlacklist, "location.ip"
+	rule, err := sdk.CreateRiskRule(models.RiskRule{Name: &name, Type: &ruleType, Target: &target, Filters: []string{"10.0.0.0/8"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *rule.ID != "8b5c0f4a-1d2e-4f3a-9b6c-7d8e9f0a1b2c" || rule.Filters[0] != "10.0.0.0/8" {
+		t.Fatalf("Unexpected rule: %+v", rule)
+	}
+	if requests[0].Method != http.MethodPost || requests[0].URL.Path != "/api/2/risk/rules" {
+		t.Fatalf("Unexpected request %s %s", requests[0].Method, requests[0].URL)
+	}
+
+	if _, err := sdk.UpdateRiskRule(*rule.ID, *rule); err != nil {
+		t.Fatal(err)
+	}
+	if err := sdk.DeleteRiskRule(*rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if requests[1].Method != http.MethodPut || requests[2].Method != http.MethodDelete || requests[2].URL.Path != "/api/2/risk/rules/"+*rule.ID {
+		t.Fatalf("Unexpected requests %s %s, %s %s", requests[1].Method, requests[1].URL, requests[2].Method, requests[2].URL)
+	}
+
+	if _, err := sdk.GetRiskRule("../users"); err == nil {
+		t.Fatal("Expected an invalid rule id to be rejected")
+	}
+}
+
+func TestVerifyRisk(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"score":74,"triggers":["New IP","Unusual location"]}`, &requests)
+
+	score, err := sdk.VerifyRisk(models.RiskContext{
+		IP:        "1.2.3.4",
+		UserAgent: "Mozilla/5.0",
+		User:      models.RiskUser{ID: "42", Name: "jane@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Score != 74 || len(score.Triggers) != 2 {
+		t.Fatalf("Unexpected score: %+v", score)
+	}
+
+	body, _ := ioutil.ReadAll(requests[0].Body)
+	var sent map[string]interface{}
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if requests[0].URL.Path != "/api/2/risk/verify" || sent["ip"] != "1.2.3.4" || sent["user_agent"] != "Mozilla/5.0" || sent["user"].(map[string]interface{})["id"] != "42" {
+		t.Fatalf("Unexpected request %s with body %s", requests[0].URL, body)
+	}
+}
+
+func TestTrackRiskEventFlattensContext(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{}`, &requests)
+
+	published := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	err := sdk.TrackRiskEvent(models.RiskEvent{
+		Verb:        models.RiskVerbLogIn,
+		RiskContext: models.RiskContext{IP: "1.2.3.4", UserAgent: "Mozilla/5.0", User: models.RiskUser{ID: "42", Authenticated: true}},
+		Published:   &published,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := ioutil.ReadAll(requests[0].Body)
+	var sent map[string]interface{}
+	if err := json.Unmarshal(body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if requests[0].URL.Path != "/api/2/risk/events" || sent["verb"] != "log-in" || sent["ip"] != "1.2.3.4" || sent["published"] != "2024-03-01T10:00:00Z" {
+		t.Fatalf("Unexpected request %s with body %s", requests[0].URL, body)
+	}
+}
+
+func TestGetRiskScores(t *testing.T) {
+	var requests []*http.Request
+	sdk := createMockSDK(http.StatusOK, `{"scores":{"minimal":10,"low":5,"medium":3,"high":1,"very_high":1},"total":20}`, &requests)
+
+	after := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	insights, err := sdk.GetRiskScores(&models.RiskScoreQuery{After: &after})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if insights.Total != 20 || insights.Scores.VeryHigh != 1 || insights.Scores.Minimal != 10 {
+		t.Fatalf("Unexpected insights: %+v", insights)
+	}
+	if requests[0].URL.Path != "/api/2/risk/scores" || requests[0].URL.Query().Get("after") != "2024-03-01T00:00:00Z" {
+		t.Fatalf("Unexpected request %s", requests[0].URL)
+	}
+}
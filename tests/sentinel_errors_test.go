@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/authentication"
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	cases := []struct {
+		status int
+		is     func(err error) bool
+	}{
+		{http.StatusNotFound, olerror.IsNotFound},
+		{http.StatusUnauthorized, olerror.IsUnauthorized},
+		{http.StatusForbidden, olerror.IsForbidden},
+		{http.StatusConflict, olerror.IsConflict},
+		{http.StatusTooManyRequests, olerror.IsRateLimited},
+	}
+	for _, c := range cases {
+		err := fmt.Errorf("wrapped: %w", olerror.NewAPIErrorFromResponse(newErrorResponse(c.status, `{}`)))
+		if !c.is(err) {
+			t.Errorf("expected a %d APIError to match its sentinel", c.status)
+		}
+		if olerror.IsNotFound(err) != (c.status == http.StatusNotFound) {
+			t.Errorf("unexpected IsNotFound result for %d", c.status)
+		}
+	}
+
+	if olerror.IsNotFound(olerror.NewAPIErrorFromResponse(newErrorResponse(http.StatusInternalServerError, `{}`))) {
+		t.Errorf("expected a 500 APIError not to match ErrNotFound")
+	}
+	if !olerror.IsUnauthorized(olerror.NewAuthenticationError("Missing client ID")) {
+		t.Errorf("expected an AuthenticationError without cause to match ErrUnauthorized")
+	}
+	rejected := olerror.NewAPIErrorFromResponse(newErrorResponse(http.StatusUnauthorized, `{}`))
+	if !olerror.IsUnauthorized(olerror.WrapAuthenticationError("Authentication failed", rejected)) {
+		t.Errorf("expected an AuthenticationError caused by a 401 to match ErrUnauthorized")
+	}
+	reset := olerror.WrapRequestError("Failed to send authentication request", errors.New("connection reset"))
+	if olerror.IsUnauthorized(olerror.WrapAuthenticationError("Access Token Retrieval Error", reset)) {
+		t.Errorf("expected an AuthenticationError caused by a network failure not to match ErrUnauthorized")
+	}
+}
+
+func TestErrorsWrapTheirCause(t *testing.T) {
+	cause := errors.New("connection reset")
+
+	for _, err := range []error{
+		olerror.WrapAuthenticationError("Failed", cause),
+		olerror.WrapRequestError("Failed", cause),
+		olerror.WrapSDKError("Failed", cause),
+		olerror.WrapSerializationError("Failed", cause),
+	} {
+		if !errors.Is(err, cause) {
+			t.Errorf("expected %T to wrap its cause", err)
+		}
+	}
+
+	if msg := olerror.WrapRequestError("Failed to send", cause).Error(); msg != "Request error: Failed to send: connection reset" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+}
+
+func TestTokenEndpointFailureIsClassified(t *testing.T) {
+	client := &MockHttpClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		return newErrorResponse(http.StatusTooManyRequests, `{"statusCode":429,"name":"TooManyRequests","message":"Rate limit exceeded"}`), nil
+	}}
+	auth := authentication.NewAuthenticatorWithConfig(authentication.Config{
+		BaseURL:      "https://api.onelogin.com",
+		ClientID:     "id",
+		ClientSecret: "secret",
+		HTTPClient:   client,
+	})
+
+	_, err := auth.Token()
+	if olerror.IsUnauthorized(err) || !olerror.IsRateLimited(err) {
+		t.Fatalf("Expected a rate limited token request not to be reported as unauthorized, got %v", err)
+	}
+	var apiErr *olerror.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "Rate limit exceeded" {
+		t.Fatalf("Expected the token endpoint's APIError as the cause, got %v", err)
+	}
+}
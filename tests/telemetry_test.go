@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/api"
+	olerror "github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/error"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/models"
+	"github.com/onelogin/onelogin-go-sdk/v4/pkg/onelogin/onelogintest"
+)
+
+func TestOperationName(t *testing.T) {
+	tests := []struct {
+		method, path, expected string
+	}{
+		{http.MethodPost, "/api/2/users", "users.create"},
+		{http.MethodGet, "/api/2/roles", "roles.list"},
+		{http.MethodGet, "/api/2/users/42", "users.get"},
+		{http.MethodGet, "/api/2/users/42/apps", "users.apps.list"},
+		{http.MethodPut, "/api/2/apps/7", "apps.update"},
+		{http.MethodDelete, "/api/2/hooks/6a0b3c1e-8f2d-4a5b-9c7d-1e2f3a4b5c6d", "hooks.delete"},
+		{http.MethodGet, "/api/1/groups", "groups.list"},
+	}
+	for _, tt := range tests {
+		if name := api.OperationName(tt.method, tt.path); name != tt.expected {
+			t.Errorf("OperationName(%s, %s) = %s, expected %s", tt.method, tt.path, name, tt.expected)
+		}
+	}
+}
+
+func TestTelemetryRecordsCalls(t *testing.T) {
+	telemetry := api.NewMemoryTelemetry()
+	client := createMockClient()
+	client.OLdomain = "https://acme.onelogin.com"
+	client.Tracer = telemetry
+	client.Metrics = telemetry
+	client.Retry = &api.RetryPolicy{
+		MaxAttempts:        3,
+		BaseDelay:          time.Millisecond,
+		RetryableStatuses:  map[int]bool{http.StatusServiceUnavailable: true},
+		RetryNonIdempotent: true,
+	}
+
+	attempts := 0
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		attempts++
+		status := http.StatusServiceUnavailable
+		if attempts == 2 {
+			status = http.StatusCreated
+		}
+		header := http.Header{}
+		header.Set(api.RateLimitLimitHeader, "5000")
+		header.Set(api.RateLimitRemainingHeader, "4998")
+		header.Set(api.RequestIDHeader, "req-7")
+		return &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"id":7}`)),
+		}, nil
+	}
+
+	if _, _, err := api.Do[*models.User](context.Background(), client, http.MethodPost, "/api/2/users", nil, models.User{Email: "jane@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := telemetry.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected one span for the call, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "users.create" || !span.Ended || len(span.Errors) != 0 {
+		t.Fatalf("Unexpected span %+v", span)
+	}
+	expected := map[string]interface{}{
+		api.AttrSubdomain:          "acme",
+		api.AttrStatusCode:         http.StatusCreated,
+		api.AttrRetries:            1,
+		api.AttrRateLimitRemaining: 4998,
+		api.AttrRequestID:          "req-7",
+	}
+	for key, value := range expected {
+		if span.Attributes[key] != value {
+			t.Errorf("Expected attribute %s = %v, got %v", key, value, span.Attributes[key])
+		}
+	}
+
+	if telemetry.Counter(api.MetricRequests) != 1 || telemetry.Counter(api.MetricRetries) != 1 || telemetry.Counter(api.MetricErrors) != 0 {
+		t.Fatalf("Unexpected counters: requests %d, retries %d, errors %d", telemetry.Counter(api.MetricRequests),
+			telemetry.Counter(api.MetricRetries), telemetry.Counter(api.MetricErrors))
+	}
+	if len(telemetry.Histogram(api.MetricDuration)) != 1 {
+		t.Fatalf("Expected one duration, got %v", telemetry.Histogram(api.MetricDuration))
+	}
+}
+
+func TestTelemetryRecordsFailures(t *testing.T) {
+	telemetry := api.NewMemoryTelemetry()
+	client := createMockClient()
+	client.Tracer = telemetry
+	client.Metrics = telemetry
+	client.HttpClient.(*MockHttpClient).DoFunc = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}, Body: http.NoBody}, nil
+	}
+
+	ctx := api.WithOperation(context.Background(), "users.lookup")
+	if _, _, err := api.Do[*models.User](ctx, client, http.MethodGet, "/api/2/users/42", nil, nil); !olerror.IsNotFound(err) {
+		t.Fatalf("Expected a not found error, got %v", err)
+	}
+
+	span := telemetry.Spans()[0]
+	if span.Name != "users.lookup" {
+		t.Fatalf("Expected the operation from the context, got %s", span.Name)
+	}
+	if len(span.Errors) != 1 || !errors.Is(span.Errors[0], olerror.ErrNotFound) {
+		t.Fatalf("Expected the span to record a not found error, got %v", span.Errors)
+	}
+	if telemetry.Counter(api.MetricErrors) != 1 {
+		t.Fatalf("Expected one error, got %d", telemetry.Counter(api.MetricErrors))
+	}
+}
+
+func TestTelemetryOptions(t *testing.T) {
+	server := newFakeServer(t)
+	telemetry := api.NewMemoryTelemetry()
+	sdk, err := onelogin.NewOneloginSDK(
+		api.WithSubdomain("acme"),
+		api.WithBaseURL(server.URL),
+		api.WithCredentials(onelogintest.ClientID, onelogintest.ClientSecret),
+		api.WithHTTPClient(server.Client()),
+		api.WithTracer(telemetry),
+		api.WithMetrics(telemetry),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sdk.GetUsers(&models.UserQuery{}); err != nil {
+		t.Fatal(err)
+	}
+	spans := telemetry.Spans()
+	if len(spans) != 1 || spans[0].Name != "users.list" || !spans[0].Ended {
+		t.Fatalf("Expected one users.list span, got %+v", spans)
+	}
+	if subdomain := spans[0].Attributes[api.AttrSubdomain]; subdomain != "acme" {
+		t.Fatalf("Expected the configured subdomain, got %v", subdomain)
+	}
+	if telemetry.Counter(api.MetricRequests) != 1 {
+		t.Fatalf("Expected one request, got %d", telemetry.Counter(api.MetricRequests))
+	}
+}